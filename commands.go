@@ -0,0 +1,293 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"sigs.k8s.io/yaml"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/bundle"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/peerauth"
+)
+
+// commandFlags are the flags of the commands run against a running registry
+type commandFlags struct {
+	url       string
+	file      string
+	format    string
+	timeout   time.Duration
+	dryRun    bool
+	keepTimes bool
+	prune     bool
+}
+
+// runCommand runs the command against the running registry using the same configuration and credentials as the
+// registry itself
+func runCommand(ctx context.Context, configPath string, args []string) {
+	config, err := loadConfig(configPath)
+	if err != nil {
+		logrus.Fatal(err.Error())
+	}
+
+	switch args[0] {
+	case "export":
+		err = exportCommand(ctx, config, args[1:])
+	case "import":
+		err = importCommand(ctx, config, args[1:])
+	case "config":
+		err = configCommand(config, args[1:])
+	default:
+		err = errors.Errorf("unknown command %q, expected export, import or config", args[0])
+	}
+	if err != nil {
+		logrus.Fatalf("%s failed: %s", args[0], err.Error())
+	}
+}
+
+func parseCommandFlags(name string, config *Config, args []string) (*commandFlags, error) {
+	f := new(commandFlags)
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(&f.url, "url", config.ListenOn[0].String(), "url of the registry")
+	flags.StringVar(&f.file, "file", "-", "path to the bundle file, - for the standard input or output")
+	flags.StringVar(&f.format, "format", "", "format of the bundle: yaml or json, detected by the file extension by default")
+	flags.DurationVar(&f.timeout, "timeout", time.Minute, "timeout of the command")
+	if name == "import" {
+		flags.BoolVar(&f.dryRun, "dry-run", false, "print the changes instead of importing the bundle")
+		flags.BoolVar(&f.keepTimes, "keep-times", false, "keep the expiration and initial registration times of the endpoints")
+		flags.BoolVar(&f.prune, "prune", false, "unregister the entries missing from the bundle")
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, errors.Wrap(err, "failed to parse flags")
+	}
+	if flags.NArg() > 0 {
+		return nil, errors.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	return f, nil
+}
+
+func (f *commandFlags) importOptions() []bundle.Option {
+	var options []bundle.Option
+	if f.keepTimes {
+		options = append(options, bundle.WithTimes())
+	}
+	if f.prune {
+		options = append(options, bundle.WithPrune())
+	}
+	return options
+}
+
+func (f *commandFlags) bundleFormat() (bundle.Format, error) {
+	switch bundle.Format(f.format) {
+	case "":
+		return bundle.FormatFromPath(f.file), nil
+	case bundle.YAML, bundle.JSON:
+		return bundle.Format(f.format), nil
+	default:
+		return "", errors.Errorf("unknown bundle format %q", f.format)
+	}
+}
+
+func exportCommand(ctx context.Context, config *Config, args []string) error {
+	f, err := parseCommandFlags("export", config, args)
+	if err != nil {
+		return err
+	}
+	format, err := f.bundleFormat()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	nsClient, nseClient, closeFunc, err := dialRegistry(ctx, config, f.url)
+	if err != nil {
+		return err
+	}
+	defer closeFunc()
+
+	b, err := bundle.Export(ctx, nsClient, nseClient)
+	if err != nil {
+		return err
+	}
+	data, err := bundle.Marshal(b, format)
+	if err != nil {
+		return err
+	}
+
+	if f.file == "-" {
+		_, err = os.Stdout.Write(data)
+		return errors.Wrap(err, "failed to write bundle")
+	}
+	if err := os.WriteFile(f.file, data, 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write bundle to %s", f.file)
+	}
+	log.FromContext(ctx).Infof("exported %d network services and %d network service endpoints to %s",
+		len(b.NetworkServices), len(b.NetworkServiceEndpoints), f.file)
+	return nil
+}
+
+func importCommand(ctx context.Context, config *Config, args []string) error {
+	f, err := parseCommandFlags("import", config, args)
+	if err != nil {
+		return err
+	}
+	format, err := f.bundleFormat()
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if f.file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(f.file)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read bundle from %s", f.file)
+	}
+	b, err := bundle.Unmarshal(data, format)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	nsClient, nseClient, closeFunc, err := dialRegistry(ctx, config, f.url)
+	if err != nil {
+		return err
+	}
+	defer closeFunc()
+
+	if !f.dryRun {
+		if err := bundle.Import(ctx, b, nsClient, nseClient, f.importOptions()...); err != nil {
+			return err
+		}
+		log.FromContext(ctx).Infof("imported %d network services and %d network service endpoints",
+			len(b.NetworkServices), len(b.NetworkServiceEndpoints))
+		return nil
+	}
+
+	current, err := bundle.Export(ctx, nsClient, nseClient)
+	if err != nil {
+		return err
+	}
+	changes := bundle.Diff(current, b, f.importOptions()...)
+	unchanged := len(b.NetworkServices) + len(b.NetworkServiceEndpoints)
+	for _, change := range changes {
+		fmt.Println(change.String())
+		if change.Op != bundle.Remove {
+			unchanged--
+		}
+	}
+	fmt.Printf("%d changes, %d entries unchanged\n", len(changes), unchanged)
+	return nil
+}
+
+func dialRegistry(ctx context.Context, config *Config, rawURL string) (registry.NetworkServiceRegistryClient,
+	registry.NetworkServiceEndpointRegistryClient, func(), error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "failed to parse url %s", rawURL)
+	}
+	authorizer, err := peerauth.NewAuthorizer(config.AllowedPeers)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to parse allowed peers")
+	}
+	source, closeSource, err := newX509Source(ctx, config)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to get x509 source")
+	}
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(u), newClientOptions(source, config, authorizer)...)
+	if err != nil {
+		closeSource()
+		return nil, nil, nil, errors.Wrapf(err, "failed to dial %s", rawURL)
+	}
+	closeFunc := func() {
+		_ = cc.Close()
+		closeSource()
+	}
+
+	nsClient := next.NewNetworkServiceRegistryClient(
+		grpcmetadata.NewNetworkServiceRegistryClient(),
+		registry.NewNetworkServiceRegistryClient(cc),
+	)
+	nseClient := next.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(cc),
+	)
+	return nsClient, nseClient, closeFunc, nil
+}
+
+func configCommand(config *Config, args []string) error {
+	if len(args) == 0 || args[0] != "dump" {
+		return errors.New("expected config dump")
+	}
+	flags := flag.NewFlagSet("config dump", flag.ContinueOnError)
+	format := flags.String("format", string(bundle.YAML), "output format: yaml or json")
+	if err := flags.Parse(args[1:]); err != nil {
+		return errors.Wrap(err, "failed to parse flags")
+	}
+	if flags.NArg() > 0 {
+		return errors.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	return dumpConfig(os.Stdout, config, bundle.Format(*format))
+}
+
+// dumpConfig writes the effective config in the format of the config file
+func dumpConfig(w io.Writer, config *Config, format bundle.Format) error {
+	values, err := configValues(config)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	switch format {
+	case bundle.YAML:
+		data, err = yaml.Marshal(values)
+	case bundle.JSON:
+		data, err = json.MarshalIndent(values, "", "  ")
+		data = append(data, '\n')
+	default:
+		return errors.Errorf("unknown config format %q", format)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal config")
+	}
+	_, err = w.Write(data)
+	return errors.Wrap(err, "failed to write config")
+}
@@ -0,0 +1,389 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"sigs.k8s.io/yaml"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/cluster"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/peerauth"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/quota"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+)
+
+const (
+	// envPrefix is the prefix of the environment variables of the config
+	envPrefix = "nsm"
+	// configFileEnv is the environment variable of the config file path, the -config flag takes precedence over it
+	configFileEnv = "NSM_CONFIG_FILE"
+
+	// svidSourceSPIRE is the SVID source getting the SVID and the trust bundles from the SPIRE agent
+	svidSourceSPIRE = "spire"
+	// svidSourceFiles is the SVID source loading the SVID and the trust bundle from the files
+	svidSourceFiles = "files"
+)
+
+// configField describes how a field of the config is set
+type configField struct {
+	// name is the name of the Config field
+	name string
+	// key is the key of the config file, e.g. listenOn
+	key string
+	// env is the environment variable, e.g. NSM_LISTEN_ON
+	env string
+}
+
+func (f *configField) String() string {
+	return fmt.Sprintf("%s (%s)", f.key, f.env)
+}
+
+// configFields returns the fields of the config in the order of declaration
+func configFields() ([]*configField, error) {
+	buf := new(bytes.Buffer)
+	if err := envconfig.Usagef(envPrefix, new(Config), buf, "{{range .}}{{.Name}} {{.Key}}\n{{end}}"); err != nil {
+		return nil, errors.Wrap(err, "failed to gather config fields")
+	}
+	var fields []*configField
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		name, env, _ := strings.Cut(scanner.Text(), " ")
+		fields = append(fields, &configField{name: name, key: configKey(name), env: env})
+	}
+	return fields, nil
+}
+
+// configKey returns the config file key of the field name, the leading upper case word is lowered: ListenOn is
+// listenOn and WALPath is walPath
+func configKey(name string) string {
+	runes := []rune(name)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// loadConfig loads the config from the defaults, the config file if its path is not empty and the environment. The
+// environment variables take precedence over the config file, which takes precedence over the defaults. The
+// environment is only read, so the configs may be loaded concurrently.
+func loadConfig(configPath string) (*Config, error) {
+	config := new(Config)
+	if err := envconfig.Process(envPrefix, config); err != nil {
+		return nil, describeParseError(err)
+	}
+	if configPath != "" {
+		if err := applyConfigFile(config, configPath); err != nil {
+			return nil, err
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// applyConfigFile reads the YAML or JSON config file and sets its values to the fields of the config whose
+// environment variables are not set
+func applyConfigFile(config *Config, configPath string) error {
+	fields, err := configFields()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Clean(configPath))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read config file %s", configPath)
+	}
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse config file %s", configPath)
+	}
+	var values map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return errors.Wrapf(err, "config file %s should be a map of the config keys", configPath)
+	}
+
+	byKey := make(map[string]*configField, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+	v := reflect.ValueOf(config).Elem()
+	var errs []string
+	for key, value := range values {
+		f, ok := byKey[key]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unknown key %q", key))
+			continue
+		}
+		if list, ok := value.([]interface{}); value == nil || ok && len(list) == 0 {
+			// An empty value keeps the default
+			continue
+		}
+		if _, ok := os.LookupEnv(f.env); ok {
+			continue
+		}
+		if err := setConfigValue(v.FieldByName(f.name), value); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid value %q of key %s of config file %s: %s", fmt.Sprint(value), key,
+				configPath, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.Errorf("invalid config file %s:\n  %s", configPath, strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// setConfigValue sets the value of the config file to the field, the scalars are parsed as the environment variables
+// and a scalar of a list is split by commas
+func setConfigValue(v reflect.Value, value interface{}) error {
+	if v.Kind() == reflect.Slice {
+		items, ok := value.([]interface{})
+		if s, isString := value.(string); !ok && isString {
+			for _, item := range strings.Split(s, ",") {
+				items = append(items, item)
+			}
+		} else if !ok {
+			return errors.New("value should be a list")
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setConfigValue(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	var s string
+	switch value := value.(type) {
+	case string, bool, json.Number:
+		s = fmt.Sprint(value)
+	default:
+		return errors.New("value should be a scalar")
+	}
+	switch field := v.Addr().Interface().(type) {
+	case *time.Duration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return errors.Wrap(err, "expected duration")
+		}
+		*field = d
+		return nil
+	case *url.URL:
+		u, err := url.Parse(s)
+		if err != nil {
+			return errors.Wrap(err, "expected url")
+		}
+		*field = *u
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return errors.New("expected bool")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, v.Type().Bits())
+		if err != nil {
+			return errors.New("expected integer")
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return errors.New("expected number")
+		}
+		v.SetFloat(f)
+	default:
+		return errors.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// describeParseError names the environment variable the invalid value comes from
+func describeParseError(err error) error {
+	var parseErr *envconfig.ParseError
+	if !errors.As(err, &parseErr) {
+		return errors.Wrap(err, "failed to process config")
+	}
+	return errors.Errorf("invalid value %q of environment variable %s: expected %s: %s", parseErr.Value,
+		parseErr.KeyName, parseErr.TypeName, parseErr.Err.Error())
+}
+
+// Validate checks the values of the config and returns all found problems
+func (c *Config) Validate() error {
+	fields, err := configFields()
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]*configField, len(fields))
+	for _, f := range fields {
+		byName[f.name] = f
+	}
+	var errs []string
+	check := func(ok bool, name, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Sprintf("%s: %s", byName[name], fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(len(c.ListenOn) > 0, "ListenOn", "at least one url is required")
+	for i := range c.ListenOn {
+		check(c.ListenOn[i].Scheme == "unix" || c.ListenOn[i].Scheme == "tcp", "ListenOn",
+			"url %q should have unix or tcp scheme", c.ListenOn[i].String())
+	}
+	for name, d := range map[string]time.Duration{
+		"MaxTokenLifetime":      c.MaxTokenLifetime,
+		"DrainTimeout":          c.DrainTimeout,
+		"DefaultExpiration":     c.DefaultExpiration,
+		"MaxExpiration":         c.MaxExpiration,
+		"MetricsExportInterval": c.MetricsExportInterval,
+		"SnapshotInterval":      c.SnapshotInterval,
+		"PeersSyncInterval":     c.PeersSyncInterval,
+		"HealthCheckInterval":   c.HealthCheckInterval,
+	} {
+		check(d > 0, name, "should be positive, got %v", d)
+	}
+	for name, d := range map[string]time.Duration{
+		"ExpirePeriod":          c.ExpirePeriod,
+		"ExpirationGracePeriod": c.ExpirationGracePeriod,
+		"TombstoneTTL":          c.TombstoneTTL,
+	} {
+		check(d >= 0, name, "should not be negative, got %v", d)
+	}
+	check(c.DefaultExpiration <= c.MaxExpiration, "DefaultExpiration", "should not exceed max expiration %v", c.MaxExpiration)
+
+	_, err = logrus.ParseLevel(c.LogLevel)
+	check(err == nil, "LogLevel", "unknown log level %q", c.LogLevel)
+
+	check(c.SVIDSource == svidSourceSPIRE || c.SVIDSource == svidSourceFiles, "SVIDSource",
+		"should be %s or %s, got %q", svidSourceSPIRE, svidSourceFiles, c.SVIDSource)
+	for name, path := range map[string]string{
+		"SVIDCertPath":    c.SVIDCertPath,
+		"SVIDKeyPath":     c.SVIDKeyPath,
+		"TrustBundlePath": c.TrustBundlePath,
+	} {
+		check(c.SVIDSource != svidSourceFiles || path != "", name, "is required by %s SVID source", svidSourceFiles)
+	}
+
+	check(c.WALPath == "" || c.SnapshotPath != "", "WALPath", "requires snapshot path")
+	check(c.WALCompactionThreshold > 0, "WALCompactionThreshold", "should be positive, got %d", c.WALCompactionThreshold)
+	check(c.StorageBackend == storage.MemoryBackend || c.StorageBackend == storage.BoltBackend, "StorageBackend",
+		"should be %s or %s, got %q", storage.MemoryBackend, storage.BoltBackend, c.StorageBackend)
+	check(c.StorageBackend != storage.BoltBackend || c.StoragePath != "", "StoragePath", "is required by %s backend", storage.BoltBackend)
+
+	check(c.ClusterNodeID == "" || len(c.ClusterMembers) > 0, "ClusterMembers", "are required in clustered mode")
+	_, err = cluster.ParseMembers(c.ClusterMembers)
+	check(err == nil, "ClusterMembers", "%v", err)
+
+	_, err = peerauth.NewAuthorizer(c.AllowedPeers)
+	check(err == nil, "AllowedPeers", "%v", err)
+	_, err = peerauth.NewAuthorizer(c.ProxyAllowedPeers)
+	check(err == nil, "ProxyAllowedPeers", "%v", err)
+
+	for _, id := range c.AdminSpiffeIDs {
+		_, err = spiffeid.FromString(id)
+		check(err == nil, "AdminSpiffeIDs", "invalid SPIFFE ID %q: %v", id, err)
+	}
+
+	check(c.RegisterRateLimit >= 0, "RegisterRateLimit", "should not be negative, got %v", c.RegisterRateLimit)
+	check(c.RegisterRateLimit == 0 || c.RegisterBurst > 0, "RegisterBurst", "should be positive, got %d", c.RegisterBurst)
+	check(c.FindRateLimit >= 0, "FindRateLimit", "should not be negative, got %v", c.FindRateLimit)
+	check(c.FindRateLimit == 0 || c.FindBurst > 0, "FindBurst", "should be positive, got %d", c.FindBurst)
+
+	for name, n := range map[string]int{
+		"MaxNSEs":           c.MaxNSEs,
+		"MaxNSEsPerID":      c.MaxNSEsPerID,
+		"MaxNSEsPerService": c.MaxNSEsPerService,
+	} {
+		check(n >= 0, name, "should not be negative, got %d", n)
+	}
+	_, err = quota.ParseTrustDomainLimits(c.TrustDomainMaxNSEs)
+	check(err == nil, "TrustDomainMaxNSEs", "%v", err)
+
+	check(c.AuditLogMaxSize > 0, "AuditLogMaxSize", "should be positive, got %d", c.AuditLogMaxSize)
+	check(c.AuditLogMaxBackups >= 0, "AuditLogMaxBackups", "should not be negative, got %d", c.AuditLogMaxBackups)
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.Errorf("invalid config:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// configValues returns the values of the config by the config file keys in the format of the config file
+func configValues(c *Config) (map[string]interface{}, error) {
+	fields, err := configFields()
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(c).Elem()
+	values := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		values[f.key] = dumpValue(v.FieldByName(f.name))
+	}
+	return values, nil
+}
+
+func dumpValue(v reflect.Value) interface{} {
+	switch value := v.Addr().Interface().(type) {
+	case *time.Duration:
+		return value.String()
+	case *url.URL:
+		return value.String()
+	}
+	if v.Kind() == reflect.Slice {
+		items := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, dumpValue(v.Index(i)))
+		}
+		return items
+	}
+	return v.Interface()
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/bundle"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+	return configPath
+}
+
+func TestConfigKey(t *testing.T) {
+	for name, key := range map[string]string{
+		"ListenOn":               "listenOn",
+		"WALPath":                "walPath",
+		"TTLPoliciesPath":        "ttlPoliciesPath",
+		"AdminSpiffeIDs":         "adminSpiffeIDs",
+		"PprofEnabled":           "pprofEnabled",
+		"WALCompactionThreshold": "walCompactionThreshold",
+	} {
+		require.Equal(t, key, configKey(name))
+	}
+}
+
+func TestLoadConfig_Precedence(t *testing.T) {
+	configPath := writeConfigFile(t, `
+listenOn:
+  - tcp://127.0.0.1:5002
+  - unix:///var/run/registry.sock
+defaultExpiration: 30s
+logLevel: DEBUG
+adminSpiffeIDs: [spiffe://example.org/admin]
+walCompactionThreshold: 10
+pprofEnabled: true
+`)
+	t.Setenv("NSM_LOG_LEVEL", "WARN")
+
+	config, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	require.Len(t, config.ListenOn, 2)
+	require.Equal(t, "tcp://127.0.0.1:5002", config.ListenOn[0].String())
+	require.Equal(t, 30*time.Second, config.DefaultExpiration)
+	require.Equal(t, "WARN", config.LogLevel)
+	require.Equal(t, []string{"spiffe://example.org/admin"}, config.AdminSpiffeIDs)
+	require.Equal(t, 10, config.WALCompactionThreshold)
+	require.True(t, config.PprofEnabled)
+	require.Equal(t, time.Hour, config.MaxExpiration)
+
+	// The environment is not changed by the config file
+	_, ok := os.LookupEnv("NSM_DEFAULT_EXPIRATION")
+	require.False(t, ok)
+	require.Equal(t, "WARN", os.Getenv("NSM_LOG_LEVEL"))
+}
+
+func TestLoadConfig_ListItemsWithCommas(t *testing.T) {
+	config, err := loadConfig(writeConfigFile(t, "allowedPeers: ['regex:spiffe://example\\.org/nsmgr-[0-9]{1,3}']\n"))
+	require.NoError(t, err)
+	require.Equal(t, []string{`regex:spiffe://example\.org/nsmgr-[0-9]{1,3}`}, config.AllowedPeers)
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	_, err := loadConfig(writeConfigFile(t, "listenOn: tcp://127.0.0.1:5002\nlistenAddress: :5002\n"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unknown key "listenAddress"`)
+
+	_, err = loadConfig(writeConfigFile(t, "maxExpiration: 1 hour\n"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "key maxExpiration of config file")
+
+	t.Setenv("NSM_MAX_EXPIRATION", "1 hour")
+	_, err = loadConfig("")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "environment variable NSM_MAX_EXPIRATION")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	_, err := loadConfig(writeConfigFile(t, `
+listenOn: http://127.0.0.1:5002
+defaultExpiration: 2h
+logLevel: LOUD
+walPath: /var/lib/registry/wal
+storageBackend: bolt
+clusterNodeID: node-1
+adminSpiffeIDs: [admin]
+auditLogMaxBackups: -1
+maxNSEsPerID: -1
+trustDomainMaxNSEs: [example.org]
+allowedPeers: [example.org]
+svidSource: files
+svidCertPath: /etc/registry/cert.pem
+`))
+	require.Error(t, err)
+	for _, problem := range []string{
+		`listenOn (NSM_LISTEN_ON): url "http://127.0.0.1:5002" should have unix or tcp scheme`,
+		"defaultExpiration (NSM_DEFAULT_EXPIRATION): should not exceed max expiration 1h0m0s",
+		`logLevel (NSM_LOG_LEVEL): unknown log level "LOUD"`,
+		"walPath (NSM_WAL_PATH): requires snapshot path",
+		"storagePath (NSM_STORAGE_PATH): is required by bolt backend",
+		"clusterMembers (NSM_CLUSTER_MEMBERS): are required in clustered mode",
+		`adminSpiffeIDs (NSM_ADMIN_SPIFFE_IDS): invalid SPIFFE ID "admin"`,
+		"auditLogMaxBackups (NSM_AUDIT_LOG_MAX_BACKUPS): should not be negative, got -1",
+		"maxNSEsPerID (NSM_MAX_NSES_PER_ID): should not be negative, got -1",
+		`trustDomainMaxNSEs (NSM_TRUST_DOMAIN_MAX_NSES): invalid trust domain limit "example.org"`,
+		`allowedPeers (NSM_ALLOWED_PEERS): invalid peer rule "example.org"`,
+		"svidKeyPath (NSM_SVID_KEY_PATH): is required by files SVID source",
+		"trustBundlePath (NSM_TRUST_BUNDLE_PATH): is required by files SVID source",
+	} {
+		require.Contains(t, err.Error(), problem)
+	}
+}
+
+func TestDumpConfig(t *testing.T) {
+	configPath := writeConfigFile(t, "listenOn: tcp://127.0.0.1:5002\nclusterMembers: [a=127.0.0.1:7001, b=127.0.0.1:7002]\n")
+	config, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	for _, format := range []bundle.Format{bundle.YAML, bundle.JSON} {
+		buf := new(bytes.Buffer)
+		require.NoError(t, dumpConfig(buf, config, format))
+		require.Contains(t, buf.String(), "127.0.0.1:7002")
+
+		dumped, err := loadConfig(writeConfigFile(t, buf.String()))
+		require.NoError(t, err)
+		require.Equal(t, config, dumped)
+	}
+}
+
+func TestConfigReloader(t *testing.T) {
+	level := logrus.GetLevel()
+	t.Cleanup(func() { logrus.SetLevel(level) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configPath := writeConfigFile(t, "logLevel: INFO\ndefaultExpiration: 1m\n")
+	config, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	registryServer := registryserver.NewServer(ctx, sandbox.GenerateTestToken, registryserver.WithMaxExpiration(config.MaxExpiration))
+	reloader := newConfigReloader(ctx, configPath, config, registryServer, new(pprofServer))
+
+	// Invalid config is not applied
+	require.NoError(t, os.WriteFile(configPath, []byte("logLevel: DEBUG\ndefaultExpiration: 2h\n"), 0o600))
+	require.Error(t, reloader.Reload(ctx))
+	require.Equal(t, config, reloader.current)
+
+	// Settings requiring a restart are kept
+	require.NoError(t, os.WriteFile(configPath, []byte("logLevel: DEBUG\ndefaultExpiration: 2m\nstorageBackend: bolt\nstoragePath: registry.db\n"), 0o600))
+	require.NoError(t, reloader.Reload(ctx))
+	require.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+	require.Equal(t, 2*time.Minute, reloader.current.DefaultExpiration)
+	require.Equal(t, "memory", reloader.current.StorageBackend)
+	require.Empty(t, reloader.current.StoragePath)
+
+	before := time.Now()
+	resp, err := registryServer.NetworkServiceEndpointRegistryServer().Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+	})
+	require.NoError(t, err)
+	require.False(t, resp.GetExpirationTime().AsTime().Before(before.Add(2*time.Minute)))
+
+	// The concurrent reloads on the file change and on the signal apply the values of the file
+	require.NoError(t, os.WriteFile(configPath, []byte("logLevel: WARN\ndefaultExpiration: 3m\n"), 0o600))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = reloader.Reload(ctx)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, logrus.WarnLevel, logrus.GetLevel())
+	require.Equal(t, 3*time.Minute, reloader.current.DefaultExpiration)
+}
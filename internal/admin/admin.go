@@ -0,0 +1,322 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin provides the operator-facing gRPC service listing, inspecting and force-removing the registry entries
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/jsonrpc"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/peerauth"
+)
+
+// Kind is the kind of registry entries
+type Kind string
+
+const (
+	// NetworkServiceKind is the kind of network services
+	NetworkServiceKind Kind = "ns"
+	// NetworkServiceEndpointKind is the kind of network service endpoints
+	NetworkServiceEndpointKind Kind = "nse"
+)
+
+// Registry is a registry managed by the admin server
+type Registry interface {
+	// Dump returns all network services and network service endpoints stored by the registry
+	Dump(ctx context.Context) ([]*registry.NetworkService, []*registry.NetworkServiceEndpoint, error)
+	// Remove deletes network services and network service endpoints from the registry
+	Remove(ctx context.Context, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error
+}
+
+// Counts is the number of the registry entries
+type Counts struct {
+	NetworkServices         int `json:"network_services"`
+	NetworkServiceEndpoints int `json:"network_service_endpoints"`
+}
+
+// Stats are the statistics of the registry entries
+type Stats struct {
+	Counts
+	// EndpointsByNetworkService is the number of endpoints registered for each network service
+	EndpointsByNetworkService map[string]int `json:"endpoints_by_network_service"`
+	// ExpiredEndpoints is the number of endpoints with the expiration time in the past
+	ExpiredEndpoints int `json:"expired_endpoints"`
+	// NextExpiration is the closest expiration time of the endpoints
+	NextExpiration *time.Time `json:"next_expiration,omitempty"`
+	// StartTime is the start time of the registry
+	StartTime time.Time `json:"start_time"`
+}
+
+type entryRequest struct {
+	Kind Kind   `json:"kind"`
+	Name string `json:"name"`
+}
+
+type listResponse struct {
+	NetworkServices         []json.RawMessage `json:"network_services"`
+	NetworkServiceEndpoints []json.RawMessage `json:"network_service_endpoints"`
+}
+
+// Server is the admin server of the registry
+type Server struct {
+	registry          Registry
+	adminIDs          []string
+	policies          []Policy
+	onDenied          func(policy string)
+	onForceUnregister func(ctx context.Context, kind Kind, name string)
+	startTime         time.Time
+}
+
+// New creates the admin server of the registry. It is authorized by the default policy, so only the admin IDs are
+// allowed unless the policies are replaced.
+func New(r Registry, opts ...Option) *Server {
+	s := &Server{
+		registry:          r,
+		policies:          []Policy{DefaultPolicy()},
+		onDenied:          func(string) {},
+		onForceUnregister: func(context.Context, Kind, string) {},
+		startTime:         time.Now(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register registers the admin service
+func (s *Server) Register(server *grpc.Server) {
+	jsonrpc.Register(server, serviceName,
+		jsonrpc.Method{Name: "ListAll", Handler: s.ListAll},
+		jsonrpc.Method{Name: "Get", Handler: s.Get},
+		jsonrpc.Method{Name: "ForceUnregister", Handler: s.ForceUnregister},
+		jsonrpc.Method{Name: "Count", Handler: s.Count},
+		jsonrpc.Method{Name: "Stats", Handler: s.Stats},
+	)
+}
+
+// ListAll returns all stored network services and network service endpoints
+func (s *Server) ListAll(ctx context.Context, _ *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	if err := s.authorize(ctx, "ListAll"); err != nil {
+		return nil, err
+	}
+	nss, nses, err := s.registry.Dump(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &listResponse{
+		NetworkServices:         make([]json.RawMessage, 0, len(nss)),
+		NetworkServiceEndpoints: make([]json.RawMessage, 0, len(nses)),
+	}
+	for _, ns := range nss {
+		data, marshalErr := protojson.Marshal(ns)
+		if marshalErr != nil {
+			return nil, status.Error(codes.Internal, marshalErr.Error())
+		}
+		resp.NetworkServices = append(resp.NetworkServices, data)
+	}
+	for _, nse := range nses {
+		data, marshalErr := protojson.Marshal(nse)
+		if marshalErr != nil {
+			return nil, status.Error(codes.Internal, marshalErr.Error())
+		}
+		resp.NetworkServiceEndpoints = append(resp.NetworkServiceEndpoints, data)
+	}
+	return marshal(resp)
+}
+
+// Get returns the stored entry of the requested kind and name
+func (s *Server) Get(ctx context.Context, in *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	if err := s.authorize(ctx, "Get"); err != nil {
+		return nil, err
+	}
+	req, err := parseEntryRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.find(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := protojson.Marshal(entry)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return wrapperspb.Bytes(data), nil
+}
+
+// ForceUnregister removes the stored entry of the requested kind and name bypassing the registry authorization
+func (s *Server) ForceUnregister(ctx context.Context, in *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	if err := s.authorize(ctx, "ForceUnregister"); err != nil {
+		return nil, err
+	}
+	req, err := parseEntryRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.find(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e := entry.(type) {
+	case *registry.NetworkService:
+		err = s.registry.Remove(ctx, []*registry.NetworkService{e}, nil)
+	case *registry.NetworkServiceEndpoint:
+		err = s.registry.Remove(ctx, nil, []*registry.NetworkServiceEndpoint{e})
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.FromContext(ctx).Infof("admin force unregistered %s %s", req.Kind, req.Name)
+	s.onForceUnregister(ctx, req.Kind, req.Name)
+	return new(wrapperspb.BytesValue), nil
+}
+
+// Count returns the number of the stored network services and network service endpoints
+func (s *Server) Count(ctx context.Context, _ *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	if err := s.authorize(ctx, "Count"); err != nil {
+		return nil, err
+	}
+	nss, nses, err := s.registry.Dump(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return marshal(&Counts{
+		NetworkServices:         len(nss),
+		NetworkServiceEndpoints: len(nses),
+	})
+}
+
+// Stats returns the statistics of the stored network services and network service endpoints
+func (s *Server) Stats(ctx context.Context, _ *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	if err := s.authorize(ctx, "Stats"); err != nil {
+		return nil, err
+	}
+	nss, nses, err := s.registry.Dump(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	stats := &Stats{
+		Counts: Counts{
+			NetworkServices:         len(nss),
+			NetworkServiceEndpoints: len(nses),
+		},
+		EndpointsByNetworkService: make(map[string]int),
+		StartTime:                 s.startTime,
+	}
+	now := time.Now()
+	for _, nse := range nses {
+		for _, ns := range nse.GetNetworkServiceNames() {
+			stats.EndpointsByNetworkService[ns]++
+		}
+		if nse.GetExpirationTime() == nil {
+			continue
+		}
+		expirationTime := nse.GetExpirationTime().AsTime()
+		if expirationTime.Before(now) {
+			stats.ExpiredEndpoints++
+			continue
+		}
+		if stats.NextExpiration == nil || expirationTime.Before(*stats.NextExpiration) {
+			stats.NextExpiration = &expirationTime
+		}
+	}
+	return marshal(stats)
+}
+
+func (s *Server) authorize(ctx context.Context, method string) error {
+	id, err := peerauth.PeerID(ctx)
+	if err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if len(s.policies) == 0 {
+		return status.Error(codes.PermissionDenied, "no admin policies")
+	}
+	input := &Input{
+		SpiffeID:       id.String(),
+		Method:         method,
+		AdminSpiffeIDs: s.adminIDs,
+	}
+	for _, policy := range s.policies {
+		if err := policy.Check(ctx, input); err != nil {
+			log.FromContext(ctx).Warnf("admin policy %s denied %s to %s", policy.Name(), method, id)
+			s.onDenied(policy.Name())
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *Server) find(ctx context.Context, req *entryRequest) (proto.Message, error) {
+	nss, nses, err := s.registry.Dump(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	switch req.Kind {
+	case NetworkServiceKind:
+		for _, ns := range nss {
+			if ns.GetName() == req.Name {
+				return ns, nil
+			}
+		}
+	case NetworkServiceEndpointKind:
+		for _, nse := range nses {
+			if nse.GetName() == req.Name {
+				return nse, nil
+			}
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "%s %s is not found", req.Kind, req.Name)
+}
+
+func parseEntryRequest(in *wrapperspb.BytesValue) (*entryRequest, error) {
+	req := new(entryRequest)
+	if err := json.Unmarshal(in.GetValue(), req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	switch req.Kind {
+	case NetworkServiceKind, NetworkServiceEndpointKind:
+		return req, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown kind %q", req.Kind)
+	}
+}
+
+func marshal(v interface{}) (*wrapperspb.BytesValue, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, status.Error(codes.Internal, errors.Wrap(err, "failed to marshal response").Error())
+	}
+	return wrapperspb.Bytes(data), nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/admin"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+var adminID = spiffeid.RequireFromString("spiffe://test.com/admin")
+
+// serve serves the admin service to the client presenting the SPIFFE ID
+func serve(ctx context.Context, t *testing.T, s *admin.Server, clientID spiffeid.ID) *grpc.ClientConn {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	clientURL := clientID.URL()
+	authInfo := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{clientURL}}},
+	}}
+	server := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(peer.NewContext(ctx, &peer.Peer{AuthInfo: authInfo}), req)
+		}))
+	s.Register(server)
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(server.Stop)
+
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+	return cc
+}
+
+func TestAdmin(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	registryServer := registryserver.NewServer(ctx, sandbox.GenerateTestToken)
+	require.NoError(t, registryServer.Restore(context.Background(),
+		[]*registry.NetworkService{{Name: "ns-1"}, {Name: "ns-2"}},
+		[]*registry.NetworkServiceEndpoint{
+			{
+				Name:                "nse-1",
+				NetworkServiceNames: []string{"ns-1"},
+				ExpirationTime:      timestamppb.New(time.Now().Add(time.Hour)),
+			},
+			{
+				Name:                "nse-2",
+				NetworkServiceNames: []string{"ns-1", "ns-2"},
+				ExpirationTime:      timestamppb.New(time.Now().Add(time.Hour)),
+			},
+		}))
+
+	var removed []string
+	cc := serve(ctx, t, admin.New(registryServer, admin.WithAdminIDs(adminID),
+		admin.WithOnForceUnregister(func(_ context.Context, kind admin.Kind, name string) {
+			removed = append(removed, string(kind)+"/"+name)
+		})), adminID)
+
+	nss, nses, err := admin.ListAll(ctx, cc)
+	require.NoError(t, err)
+	require.Len(t, nss, 2)
+	require.Len(t, nses, 2)
+
+	nse, err := admin.GetNetworkServiceEndpoint(ctx, cc, "nse-2")
+	require.NoError(t, err)
+	require.Equal(t, []string{"ns-1", "ns-2"}, nse.GetNetworkServiceNames())
+
+	_, err = admin.GetNetworkService(ctx, cc, "ns-3")
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	stats, err := admin.GetStats(ctx, cc)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"ns-1": 2, "ns-2": 1}, stats.EndpointsByNetworkService)
+	require.Zero(t, stats.ExpiredEndpoints)
+	require.NotNil(t, stats.NextExpiration)
+
+	require.NoError(t, admin.ForceUnregister(ctx, cc, admin.NetworkServiceEndpointKind, "nse-1"))
+	require.Equal(t, codes.NotFound, status.Code(admin.ForceUnregister(ctx, cc, admin.NetworkServiceEndpointKind, "nse-1")))
+	require.Equal(t, []string{"nse/nse-1"}, removed)
+
+	counts, err := admin.Count(ctx, cc)
+	require.NoError(t, err)
+	require.Equal(t, &admin.Counts{NetworkServices: 2, NetworkServiceEndpoints: 1}, counts)
+}
+
+func TestAdmin_DeniesNotAdmins(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	registryServer := registryserver.NewServer(ctx, sandbox.GenerateTestToken)
+	require.NoError(t, registryServer.Restore(context.Background(), []*registry.NetworkService{{Name: "ns-1"}}, nil))
+
+	clientID := spiffeid.RequireFromString("spiffe://test.com/nse")
+	cc := serve(ctx, t, admin.New(registryServer, admin.WithAdminIDs(adminID)), clientID)
+
+	_, _, err := admin.ListAll(ctx, cc)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = admin.ForceUnregister(ctx, cc, admin.NetworkServiceKind, "ns-1")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	nss, _, err := registryServer.Dump(ctx)
+	require.NoError(t, err)
+	require.Len(t, nss, 1)
+
+	// Without policies all clients are denied
+	cc = serve(ctx, t, admin.New(registryServer, admin.WithAdminIDs(clientID), admin.WithPolicies()), clientID)
+	_, err = admin.Count(ctx, cc)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
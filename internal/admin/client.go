@@ -0,0 +1,106 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/jsonrpc"
+)
+
+// ListAll returns all entries stored by the registry reachable through the connection
+func ListAll(ctx context.Context, cc grpc.ClientConnInterface) ([]*registry.NetworkService, []*registry.NetworkServiceEndpoint, error) {
+	resp := new(listResponse)
+	if err := jsonrpc.Invoke(ctx, cc, listAllMethod, nil, resp); err != nil {
+		return nil, nil, err
+	}
+	nss := make([]*registry.NetworkService, 0, len(resp.NetworkServices))
+	for _, data := range resp.NetworkServices {
+		ns := new(registry.NetworkService)
+		if err := protojson.Unmarshal(data, ns); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to unmarshal network service")
+		}
+		nss = append(nss, ns)
+	}
+	nses := make([]*registry.NetworkServiceEndpoint, 0, len(resp.NetworkServiceEndpoints))
+	for _, data := range resp.NetworkServiceEndpoints {
+		nse := new(registry.NetworkServiceEndpoint)
+		if err := protojson.Unmarshal(data, nse); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to unmarshal network service endpoint")
+		}
+		nses = append(nses, nse)
+	}
+	return nss, nses, nil
+}
+
+// GetNetworkService returns the network service stored by the registry reachable through the connection
+func GetNetworkService(ctx context.Context, cc grpc.ClientConnInterface, name string) (*registry.NetworkService, error) {
+	ns := new(registry.NetworkService)
+	if err := get(ctx, cc, NetworkServiceKind, name, ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+// GetNetworkServiceEndpoint returns the network service endpoint stored by the registry reachable through the
+// connection
+func GetNetworkServiceEndpoint(ctx context.Context, cc grpc.ClientConnInterface, name string) (*registry.NetworkServiceEndpoint, error) {
+	nse := new(registry.NetworkServiceEndpoint)
+	if err := get(ctx, cc, NetworkServiceEndpointKind, name, nse); err != nil {
+		return nil, err
+	}
+	return nse, nil
+}
+
+// ForceUnregister removes the entry from the registry reachable through the connection
+func ForceUnregister(ctx context.Context, cc grpc.ClientConnInterface, kind Kind, name string) error {
+	return jsonrpc.Invoke(ctx, cc, forceUnregisterMethod, &entryRequest{Kind: kind, Name: name}, nil)
+}
+
+// Count returns the number of entries stored by the registry reachable through the connection
+func Count(ctx context.Context, cc grpc.ClientConnInterface) (*Counts, error) {
+	counts := new(Counts)
+	if err := jsonrpc.Invoke(ctx, cc, countMethod, nil, counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// GetStats returns the statistics of the registry reachable through the connection
+func GetStats(ctx context.Context, cc grpc.ClientConnInterface) (*Stats, error) {
+	stats := new(Stats)
+	if err := jsonrpc.Invoke(ctx, cc, statsMethod, nil, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func get(ctx context.Context, cc grpc.ClientConnInterface, kind Kind, name string, entry proto.Message) error {
+	var out json.RawMessage
+	if err := jsonrpc.Invoke(ctx, cc, getMethod, &entryRequest{Kind: kind, Name: name}, &out); err != nil {
+		return err
+	}
+	return errors.Wrapf(protojson.Unmarshal(out, entry), "failed to unmarshal %s %s", kind, name)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+)
+
+// Option modifies the admin server option value
+type Option func(s *Server)
+
+// WithAdminIDs sets the SPIFFE IDs allowed by the default admin policy
+func WithAdminIDs(ids ...spiffeid.ID) Option {
+	return func(s *Server) {
+		s.adminIDs = s.adminIDs[:0]
+		for _, id := range ids {
+			s.adminIDs = append(s.adminIDs, id.String())
+		}
+	}
+}
+
+// WithPolicies replaces the default admin policy. All clients are denied if there are no policies.
+func WithPolicies(policies ...Policy) Option {
+	return func(s *Server) {
+		s.policies = policies
+	}
+}
+
+// WithOnForceUnregister sets the function called with the context of the request, the kind and the name of each entry
+// removed by ForceUnregister
+func WithOnForceUnregister(onForceUnregister func(ctx context.Context, kind Kind, name string)) Option {
+	return func(s *Server) {
+		s.onForceUnregister = onForceUnregister
+	}
+}
+
+// WithOnDenied sets the function called with the name of the policy denying a request
+func WithOnDenied(onDenied func(policy string)) Option {
+	return func(s *Server) {
+		s.onDenied = onDenied
+	}
+}
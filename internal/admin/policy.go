@@ -0,0 +1,63 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+//go:embed policies/admin_allowed.rego
+var adminAllowedPolicy string
+
+// Policy is an authorization policy of the admin requests
+type Policy interface {
+	// Name returns the policy name
+	Name() string
+	// Check returns an error if the input is not authorized
+	Check(ctx context.Context, input interface{}) error
+}
+
+// Input is the input of the admin policies
+type Input struct {
+	// SpiffeID is the SPIFFE ID of the client
+	SpiffeID string `json:"spiffe_id"`
+	// Method is the name of the called admin method
+	Method string `json:"method"`
+	// AdminSpiffeIDs are the SPIFFE IDs configured as admins
+	AdminSpiffeIDs []string `json:"admin_spiffe_ids"`
+}
+
+// DefaultPolicy returns the policy allowing the configured admin SPIFFE IDs
+func DefaultPolicy() Policy {
+	return opa.WithNamedPolicyFromSource("admin_allowed", adminAllowedPolicy, "valid", opa.True)
+}
+
+// PoliciesFromPaths loads the admin policies from the files and directories
+func PoliciesFromPaths(paths ...string) ([]Policy, error) {
+	opaPolicies, err := opa.PoliciesByFileMask(paths...)
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]Policy, 0, len(opaPolicies))
+	for _, policy := range opaPolicies {
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package antientropy provides eventually consistent replication of the registry entries between registry replicas.
+// The replicas periodically exchange digests of their entries and pull missing or newer entries from each other, the
+// last write wins.
+package antientropy
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/jsonrpc"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/snapshot"
+)
+
+// Registry is a registry which entries are replicated
+type Registry interface {
+	snapshot.Registry
+	// Remove deletes network services and network service endpoints from the registry
+	Remove(ctx context.Context, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error
+}
+
+// version is the time of the last registration or unregistration of the entry
+type version struct {
+	Time    int64 `json:"time"`
+	Deleted bool  `json:"deleted,omitempty"`
+}
+
+func (v version) newerThan(other version) bool {
+	if v.Time != other.Time {
+		return v.Time > other.Time
+	}
+	return v.Deleted && !other.Deleted
+}
+
+type digest struct {
+	NetworkServices         map[string]version `json:"networkServices"`
+	NetworkServiceEndpoints map[string]version `json:"networkServiceEndpoints"`
+}
+
+type pullRequest struct {
+	NetworkServices         []string `json:"networkServices"`
+	NetworkServiceEndpoints []string `json:"networkServiceEndpoints"`
+}
+
+type entry struct {
+	Version version         `json:"version"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+type pullResponse struct {
+	NetworkServices         map[string]*entry `json:"networkServices"`
+	NetworkServiceEndpoints map[string]*entry `json:"networkServiceEndpoints"`
+}
+
+// Syncer synchronizes the registry entries with the peers
+type Syncer struct {
+	peers        []*url.URL
+	interval     time.Duration
+	tombstoneTTL time.Duration
+	dialOptions  []grpc.DialOption
+	authorize    func(ctx context.Context) error
+
+	mu          sync.Mutex
+	nsVersions  map[string]version
+	nseVersions map[string]version
+
+	conns map[string]*grpc.ClientConn
+}
+
+// New creates a syncer pulling entries from the peers
+func New(peers []*url.URL, options ...Option) *Syncer {
+	s := &Syncer{
+		peers:        peers,
+		interval:     defaultInterval,
+		tombstoneTTL: defaultTombstoneTTL,
+		authorize: func(context.Context) error {
+			return errors.New("peers are not authorized")
+		},
+		nsVersions:  make(map[string]version),
+		nseVersions: make(map[string]version),
+		conns:       make(map[string]*grpc.ClientConn),
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+// Register registers the service serving the entries of the registry to the peers
+func (s *Syncer) Register(server *grpc.Server, r Registry) {
+	ss := &syncServer{syncer: s, registry: r}
+	jsonrpc.Register(server, serviceName,
+		jsonrpc.Method{Name: "Digest", Handler: ss.Digest},
+		jsonrpc.Method{Name: "Pull", Handler: ss.Pull},
+	)
+}
+
+// Run periodically pulls missing and newer entries from the peers into the registry until the context is done
+func (s *Syncer) Run(ctx context.Context, r Registry) {
+	logger := log.FromContext(ctx).WithField("antientropy", "Run")
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	defer func() {
+		for _, cc := range s.conns {
+			_ = cc.Close()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, peer := range s.peers {
+			syncCtx, cancel := context.WithTimeout(ctx, s.interval)
+			if err := s.sync(syncCtx, r, peer); err != nil {
+				logger.Warnf("failed to sync with %s: %s", peer, err.Error())
+			}
+			cancel()
+		}
+		s.removeTombstones(clock.FromContext(ctx).Now())
+	}
+}
+
+func (s *Syncer) sync(ctx context.Context, r Registry, peer *url.URL) error {
+	cc, err := s.clientConn(ctx, peer)
+	if err != nil {
+		return err
+	}
+
+	d := new(digest)
+	if err = jsonrpc.Invoke(ctx, cc, digestMethod, nil, d); err != nil {
+		return errors.Wrapf(err, "failed to call %s", digestMethod)
+	}
+	req := s.missing(d)
+	if len(req.NetworkServices) == 0 && len(req.NetworkServiceEndpoints) == 0 {
+		return nil
+	}
+
+	resp := new(pullResponse)
+	if err = jsonrpc.Invoke(ctx, cc, pullMethod, req, resp); err != nil {
+		return errors.Wrapf(err, "failed to call %s", pullMethod)
+	}
+	return s.apply(ctx, r, resp)
+}
+
+func (s *Syncer) clientConn(ctx context.Context, peer *url.URL) (*grpc.ClientConn, error) {
+	if cc, ok := s.conns[peer.String()]; ok {
+		return cc, nil
+	}
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(peer), s.dialOptions...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial %s", peer)
+	}
+	s.conns[peer.String()] = cc
+	return cc, nil
+}
+
+// missing returns names of the entries which are missing or older than the entries of the peer
+func (s *Syncer) missing(d *digest) *pullRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req := new(pullRequest)
+	for name, v := range d.NetworkServices {
+		if local, ok := s.nsVersions[name]; (ok && v.newerThan(local)) || (!ok && !v.Deleted) {
+			req.NetworkServices = append(req.NetworkServices, name)
+		}
+	}
+	for name, v := range d.NetworkServiceEndpoints {
+		if local, ok := s.nseVersions[name]; (ok && v.newerThan(local)) || (!ok && !v.Deleted) {
+			req.NetworkServiceEndpoints = append(req.NetworkServiceEndpoints, name)
+		}
+	}
+	return req
+}
+
+func (s *Syncer) apply(ctx context.Context, r Registry, resp *pullResponse) error {
+	for name, e := range resp.NetworkServices {
+		if !s.isNewer(s.nsVersions, name, e.Version) {
+			continue
+		}
+		versionCtx := withVersion(ctx, e.Version)
+		if e.Version.Deleted {
+			if err := r.Remove(versionCtx, []*registry.NetworkService{{Name: name}}, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		ns := new(registry.NetworkService)
+		if err := protojson.Unmarshal(e.Data, ns); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal network service %s", name)
+		}
+		if err := r.Restore(versionCtx, []*registry.NetworkService{ns}, nil); err != nil {
+			return err
+		}
+	}
+
+	now := clock.FromContext(ctx).Now()
+	for name, e := range resp.NetworkServiceEndpoints {
+		if !s.isNewer(s.nseVersions, name, e.Version) {
+			continue
+		}
+		versionCtx := withVersion(ctx, e.Version)
+		if e.Version.Deleted {
+			if err := r.Remove(versionCtx, nil, []*registry.NetworkServiceEndpoint{{Name: name}}); err != nil {
+				return err
+			}
+			continue
+		}
+		nse := new(registry.NetworkServiceEndpoint)
+		if err := protojson.Unmarshal(e.Data, nse); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal network service endpoint %s", name)
+		}
+		// The peer has not expired the endpoint yet, but it is going to
+		if nse.GetExpirationTime() != nil && !now.Before(nse.GetExpirationTime().AsTime()) {
+			continue
+		}
+		if err := r.Restore(versionCtx, nil, []*registry.NetworkServiceEndpoint{nse}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Syncer) isNewer(versions map[string]version, name string, v version) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	local, ok := versions[name]
+	return !ok || v.newerThan(local)
+}
+
+func (s *Syncer) update(versions map[string]version, name string, v version) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if local, ok := versions[name]; !ok || !local.newerThan(v) {
+		versions[name] = v
+	}
+}
+
+func (s *Syncer) forget(versions map[string]version, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(versions, name)
+}
+
+func (s *Syncer) removeTombstones(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deadline := now.Add(-s.tombstoneTTL).UnixNano()
+	for _, versions := range []map[string]version{s.nsVersions, s.nseVersions} {
+		for name, v := range versions {
+			if v.Deleted && v.Time < deadline {
+				delete(versions, name)
+			}
+		}
+	}
+}
+
+func (s *Syncer) digest() *digest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := &digest{
+		NetworkServices:         make(map[string]version, len(s.nsVersions)),
+		NetworkServiceEndpoints: make(map[string]version, len(s.nseVersions)),
+	}
+	for name, v := range s.nsVersions {
+		d.NetworkServices[name] = v
+	}
+	for name, v := range s.nseVersions {
+		d.NetworkServiceEndpoints[name] = v
+	}
+	return d
+}
+
+func (s *Syncer) version(versions map[string]version, name string) (version, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := versions[name]
+	return v, ok
+}
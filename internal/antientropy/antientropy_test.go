@@ -0,0 +1,197 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antientropy_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/antientropy"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+const (
+	replicaCount = 3
+	syncInterval = 100 * time.Millisecond
+	convergence  = 2 * replicaCount * syncInterval
+)
+
+// startReplicas starts the registries synchronizing with each other in a ring, so the entries travel through the
+// intermediate replicas
+func startReplicas(ctx context.Context, t *testing.T) []*registryserver.Server {
+	var listeners []net.Listener
+	var urls []*url.URL
+	for i := 0; i < replicaCount; i++ {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		listeners = append(listeners, ln)
+		urls = append(urls, &url.URL{Scheme: "tcp", Host: ln.Addr().String()})
+	}
+
+	var replicas []*registryserver.Server
+	for i := 0; i < replicaCount; i++ {
+		syncer := antientropy.New([]*url.URL{urls[(i+1)%replicaCount]},
+			antientropy.WithInterval(syncInterval),
+			antientropy.WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			antientropy.WithAuthorize(func(context.Context) error { return nil }),
+		)
+		replica := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+			registryserver.WithNSRegistryServers(antientropy.NewNetworkServiceRegistryServer(syncer)),
+			registryserver.WithNSERegistryServers(antientropy.NewNetworkServiceEndpointRegistryServer(syncer)),
+		)
+
+		server := grpc.NewServer()
+		syncer.Register(server, replica)
+		go func(ln net.Listener) { _ = server.Serve(ln) }(listeners[i])
+		t.Cleanup(server.Stop)
+
+		go syncer.Run(ctx, replica)
+		replicas = append(replicas, replica)
+	}
+	return replicas
+}
+
+func findNS(ctx context.Context, t *testing.T, replica *registryserver.Server, name string) []*registry.NetworkService {
+	stream, err := adapters.NetworkServiceServerToClient(replica.NetworkServiceRegistryServer()).Find(ctx,
+		&registry.NetworkServiceQuery{NetworkService: &registry.NetworkService{Name: name}})
+	require.NoError(t, err)
+	return registry.ReadNetworkServiceList(stream)
+}
+
+func findNSE(ctx context.Context, t *testing.T, replica *registryserver.Server, name string) []*registry.NetworkServiceEndpoint {
+	stream, err := adapters.NetworkServiceEndpointServerToClient(replica.NetworkServiceEndpointRegistryServer()).Find(ctx,
+		&registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: name}})
+	require.NoError(t, err)
+	return registry.ReadNetworkServiceEndpointList(stream)
+}
+
+func TestSyncer_NetworkServices(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicas := startReplicas(ctx, t)
+
+	_, err := adapters.NetworkServiceServerToClient(replicas[0].NetworkServiceRegistryServer()).Register(ctx,
+		&registry.NetworkService{Name: "ns-1", Payload: "IP"})
+	require.NoError(t, err)
+	for _, replica := range replicas {
+		require.Eventually(t, func() bool {
+			nss := findNS(ctx, t, replica, "ns-1")
+			return len(nss) == 1 && nss[0].GetPayload() == "IP"
+		}, convergence, syncInterval/10)
+	}
+
+	// The last write wins
+	_, err = adapters.NetworkServiceServerToClient(replicas[1].NetworkServiceRegistryServer()).Register(ctx,
+		&registry.NetworkService{Name: "ns-1", Payload: "ETHERNET"})
+	require.NoError(t, err)
+	for _, replica := range replicas {
+		require.Eventually(t, func() bool {
+			nss := findNS(ctx, t, replica, "ns-1")
+			return len(nss) == 1 && nss[0].GetPayload() == "ETHERNET"
+		}, convergence, syncInterval/10)
+	}
+
+	_, err = adapters.NetworkServiceServerToClient(replicas[2].NetworkServiceRegistryServer()).Unregister(ctx,
+		&registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+	for _, replica := range replicas {
+		require.Eventually(t, func() bool {
+			return len(findNS(ctx, t, replica, "ns-1")) == 0
+		}, convergence, syncInterval/10)
+	}
+}
+
+func TestSyncer_NetworkServiceEndpoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicas := startReplicas(ctx, t)
+
+	_, err := adapters.NetworkServiceEndpointServerToClient(replicas[0].NetworkServiceEndpointRegistryServer()).Register(ctx,
+		&registry.NetworkServiceEndpoint{
+			Name:                "nse-1",
+			NetworkServiceNames: []string{"ns-1"},
+			ExpirationTime:      timestamppb.New(time.Now().Add(time.Minute)),
+		})
+	require.NoError(t, err)
+	for _, replica := range replicas {
+		require.Eventually(t, func() bool {
+			return len(findNSE(ctx, t, replica, "nse-1")) == 1
+		}, convergence, syncInterval/10)
+	}
+
+	_, err = adapters.NetworkServiceEndpointServerToClient(replicas[1].NetworkServiceEndpointRegistryServer()).Unregister(ctx,
+		&registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	for _, replica := range replicas {
+		require.Eventually(t, func() bool {
+			return len(findNSE(ctx, t, replica, "nse-1")) == 0
+		}, convergence, syncInterval/10)
+	}
+}
+
+func TestSyncer_ExpiredNetworkServiceEndpoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicas := startReplicas(ctx, t)
+
+	// The expire chain element unregisters the endpoint earlier by the timeout of its registration request
+	requestCtx, requestCancel := context.WithTimeout(ctx, time.Second)
+	defer requestCancel()
+	_, err := adapters.NetworkServiceEndpointServerToClient(replicas[0].NetworkServiceEndpointRegistryServer()).Register(requestCtx,
+		&registry.NetworkServiceEndpoint{
+			Name:                "nse-1",
+			NetworkServiceNames: []string{"ns-1"},
+			ExpirationTime:      timestamppb.New(time.Now().Add(1500 * time.Millisecond)),
+		})
+	require.NoError(t, err)
+	for _, replica := range replicas {
+		require.Eventually(t, func() bool {
+			return len(findNSE(ctx, t, replica, "nse-1")) == 1
+		}, convergence, syncInterval/10)
+	}
+
+	// Every replica expires the endpoint and it is not resurrected by the replicas not expired it yet
+	for _, replica := range replicas {
+		require.Eventually(t, func() bool {
+			return len(findNSE(ctx, t, replica, "nse-1")) == 0
+		}, 2*time.Second, syncInterval/10)
+	}
+	require.Never(t, func() bool {
+		for _, replica := range replicas {
+			if len(findNSE(ctx, t, replica, "nse-1")) != 0 {
+				return true
+			}
+		}
+		return false
+	}, convergence, syncInterval/10)
+}
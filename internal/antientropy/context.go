@@ -0,0 +1,40 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antientropy
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+type versionKey struct{}
+
+func withVersion(ctx context.Context, v version) context.Context {
+	return context.WithValue(ctx, versionKey{}, v)
+}
+
+// versionFromContext returns the version of the entry pulled from a peer or a new version of the local change
+func versionFromContext(ctx context.Context, deleted bool) version {
+	if v, ok := ctx.Value(versionKey{}).(version); ok {
+		return v
+	}
+	return version{
+		Time:    clock.FromContext(ctx).Now().UnixNano(),
+		Deleted: deleted,
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antientropy
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type syncNSServer struct {
+	syncer *Syncer
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer chain element that tracks versions of
+// network services to synchronize them with the peers
+func NewNetworkServiceRegistryServer(s *Syncer) registry.NetworkServiceRegistryServer {
+	return &syncNSServer{
+		syncer: s,
+	}
+}
+
+func (s *syncNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	s.syncer.update(s.syncer.nsVersions, resp.GetName(), versionFromContext(ctx, false))
+	return resp, nil
+}
+
+func (s *syncNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *syncNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	s.syncer.update(s.syncer.nsVersions, ns.GetName(), versionFromContext(ctx, true))
+	return resp, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antientropy
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/expiry"
+)
+
+type syncNSEServer struct {
+	syncer *Syncer
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element that
+// tracks versions of network service endpoints to synchronize them with the peers. It should be placed after the
+// expire chain element to observe expirations.
+func NewNetworkServiceEndpointRegistryServer(s *Syncer) registry.NetworkServiceEndpointRegistryServer {
+	return &syncNSEServer{
+		syncer: s,
+	}
+}
+
+func (s *syncNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	s.syncer.update(s.syncer.nseVersions, resp.GetName(), versionFromContext(ctx, false))
+	return resp, nil
+}
+
+func (s *syncNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *syncNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiry.IsExpired(ctx) {
+		// Every replica expires the endpoint itself, so the expiration is not propagated
+		s.syncer.forget(s.syncer.nseVersions, nse.GetName())
+	} else {
+		s.syncer.update(s.syncer.nseVersions, nse.GetName(), versionFromContext(ctx, true))
+	}
+	return resp, nil
+}
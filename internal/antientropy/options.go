@@ -0,0 +1,60 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antientropy
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultInterval     = 5 * time.Second
+	defaultTombstoneTTL = time.Minute
+)
+
+// Option modifies the syncer option value
+type Option func(s *Syncer)
+
+// WithInterval sets the interval between synchronizations with the peers
+func WithInterval(d time.Duration) Option {
+	return func(s *Syncer) {
+		s.interval = d
+	}
+}
+
+// WithTombstoneTTL sets how long the unregistrations are remembered to be propagated to the peers
+func WithTombstoneTTL(d time.Duration) Option {
+	return func(s *Syncer) {
+		s.tombstoneTTL = d
+	}
+}
+
+// WithDialOptions sets grpc.DialOptions used to connect to the peers
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(s *Syncer) {
+		s.dialOptions = dialOptions
+	}
+}
+
+// WithAuthorize sets the check of the peers pulling entries from this registry. All peers are denied by default.
+func WithAuthorize(authorize func(ctx context.Context) error) Option {
+	return func(s *Syncer) {
+		s.authorize = authorize
+	}
+}
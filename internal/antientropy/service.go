@@ -0,0 +1,114 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package antientropy
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	serviceName  = "registrymemory.AntiEntropy"
+	digestMethod = "/" + serviceName + "/Digest"
+	pullMethod   = "/" + serviceName + "/Pull"
+)
+
+// syncServer serves the digests and the entries of the registry to the peers
+type syncServer struct {
+	syncer   *Syncer
+	registry Registry
+}
+
+func (s *syncServer) Digest(ctx context.Context, _ *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	if err := s.syncer.authorize(ctx); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	data, err := json.Marshal(s.syncer.digest())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return wrapperspb.Bytes(data), nil
+}
+
+func (s *syncServer) Pull(ctx context.Context, in *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	if err := s.syncer.authorize(ctx); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	req := new(pullRequest)
+	if err := json.Unmarshal(in.GetValue(), req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	nss, nses, err := s.registry.Dump(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	storedNSs := make(map[string]proto.Message, len(nss))
+	for _, ns := range nss {
+		storedNSs[ns.GetName()] = ns
+	}
+	storedNSEs := make(map[string]proto.Message, len(nses))
+	for _, nse := range nses {
+		storedNSEs[nse.GetName()] = nse
+	}
+
+	resp := new(pullResponse)
+	if resp.NetworkServices, err = s.entries(s.syncer.nsVersions, req.NetworkServices, storedNSs); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if resp.NetworkServiceEndpoints, err = s.entries(s.syncer.nseVersions, req.NetworkServiceEndpoints, storedNSEs); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return wrapperspb.Bytes(data), nil
+}
+
+// entries returns the stored entries and the tombstones of the removed entries
+func (s *syncServer) entries(versions map[string]version, names []string, stored map[string]proto.Message) (map[string]*entry, error) {
+	result := make(map[string]*entry)
+	for _, name := range names {
+		v, ok := s.syncer.version(versions, name)
+		if !ok {
+			continue
+		}
+		if v.Deleted {
+			result[name] = &entry{Version: v}
+			continue
+		}
+		msg, ok := stored[name]
+		if !ok {
+			continue
+		}
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal %s", name)
+		}
+		result[name] = &entry{Version: v, Data: data}
+	}
+	return result, nil
+}
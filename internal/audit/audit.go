@@ -0,0 +1,183 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit provides the structured JSON audit log of the registry mutations and authorization denials
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/peerauth"
+)
+
+// Event is the kind of the audited action
+type Event string
+
+const (
+	// Register is the first registration of an entry
+	Register Event = "register"
+	// Refresh is the registration of an already registered entry
+	Refresh Event = "refresh"
+	// Unregister is the unregistration of an entry by a client
+	Unregister Event = "unregister"
+	// Expire is the removal of an expired entry
+	Expire Event = "expire"
+	// ForceUnregister is the removal of an entry by an admin
+	ForceUnregister Event = "force_unregister"
+	// Denied is the request denied by the authorization
+	Denied Event = "denied"
+)
+
+const (
+	nsResource  = "ns"
+	nseResource = "nse"
+)
+
+// Record is the audit record of the action
+type Record struct {
+	Time     time.Time `json:"time"`
+	Event    Event     `json:"event"`
+	Resource string    `json:"resource"`
+	Name     string    `json:"name"`
+	// Method is the denied or failed method
+	Method string `json:"method,omitempty"`
+	// PeerSpiffeID is the SPIFFE ID of the mTLS peer
+	PeerSpiffeID string `json:"peer_spiffe_id,omitempty"`
+	// PathSpiffeID is the SPIFFE ID of the client originating the request taken from the JWT of the path
+	PathSpiffeID string `json:"path_spiffe_id,omitempty"`
+	// Error is the error of the failed request
+	Error string `json:"error,omitempty"`
+}
+
+// Auditor writes the audit records
+type Auditor struct {
+	mu         sync.Mutex
+	writer     io.Writer
+	registered sync.Map
+}
+
+// New creates the auditor writing one JSON record per line to the writer
+func New(w io.Writer) *Auditor {
+	return &Auditor{
+		writer: w,
+	}
+}
+
+func (a *Auditor) write(ctx context.Context, record *Record) {
+	record.Time = time.Now().UTC()
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to marshal audit record: %s", err.Error())
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, err := a.writer.Write(append(data, '\n')); err != nil {
+		log.FromContext(ctx).Errorf("failed to write audit record: %s", err.Error())
+	}
+}
+
+// newRecord creates the record of the request made by the client, it should be called before the request is
+// passed down the chain as the path is updated there
+func newRecord(ctx context.Context, resource, name string) *Record {
+	return &Record{
+		Resource:     resource,
+		Name:         name,
+		PeerSpiffeID: peerSpiffeID(ctx),
+		PathSpiffeID: pathSpiffeID(ctx),
+	}
+}
+
+// request records the result of the request made by the client
+func (a *Auditor) request(ctx context.Context, record *Record, method string, err error) {
+	key := record.Resource + "/" + record.Name
+	switch {
+	case status.Code(err) == codes.PermissionDenied:
+		record.Event = Denied
+		record.Method = method
+		record.Error = err.Error()
+	case err != nil:
+		record.Event = Event(strings.ToLower(method))
+		record.Method = method
+		record.Error = err.Error()
+	case method == "Unregister":
+		record.Event = Unregister
+		a.registered.Delete(key)
+	default:
+		record.Event = Register
+		if _, loaded := a.registered.LoadOrStore(key, struct{}{}); loaded {
+			record.Event = Refresh
+		}
+	}
+	a.write(ctx, record)
+}
+
+// expire records the expiration of the entry
+func (a *Auditor) expire(ctx context.Context, resource, name string) {
+	a.registered.Delete(resource + "/" + name)
+	a.write(ctx, &Record{
+		Event:    Expire,
+		Resource: resource,
+		Name:     name,
+	})
+}
+
+// ForceUnregister records the removal of the entry of the resource kind, "ns" or "nse", by the admin making the
+// request
+func (a *Auditor) ForceUnregister(ctx context.Context, resource, name string) {
+	a.registered.Delete(resource + "/" + name)
+	a.write(ctx, &Record{
+		Event:        ForceUnregister,
+		Resource:     resource,
+		Name:         name,
+		PeerSpiffeID: peerSpiffeID(ctx),
+	})
+}
+
+func peerSpiffeID(ctx context.Context) string {
+	id, err := peerauth.PeerID(ctx)
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+func pathSpiffeID(ctx context.Context) string {
+	path := grpcmetadata.PathFromContext(ctx)
+	if path == nil || len(path.PathSegments) == 0 {
+		return ""
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(path.PathSegments[0].Token, &claims); err != nil {
+		return ""
+	}
+	sub, _ := claims["sub"].(string)
+	return sub
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/audit"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+const denyPolicy = `package nsm
+
+default valid = false
+`
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) records(t *testing.T) []*audit.Record {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []*audit.Record
+	scanner := bufio.NewScanner(bytes.NewReader(b.buf.Bytes()))
+	for scanner.Scan() {
+		record := new(audit.Record)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestAuditor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The network services are denied to audit the denials
+	policyPath := filepath.Join(t.TempDir(), "deny.rego")
+	require.NoError(t, os.WriteFile(policyPath, []byte(denyPolicy), 0o600))
+
+	buf := new(syncBuffer)
+	auditor := audit.New(buf)
+	registryServer := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithAuthorizeNSRegistryServer(authorize.NewNetworkServiceRegistryServer(authorize.WithPolicies(policyPath))),
+		registryserver.WithNSFrontServers(audit.NewNetworkServiceRegistryServer(auditor)),
+		registryserver.WithNSEFrontServers(audit.NewNetworkServiceEndpointRegistryServer(auditor)),
+		registryserver.WithNSERegistryServers(audit.NewExpirationNetworkServiceEndpointRegistryServer(auditor)),
+	)
+
+	peerID := spiffeid.RequireFromString("spiffe://test.com/forwarder")
+	authInfo := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{peerID.URL()}}},
+	}}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			return handler(peer.NewContext(ctx, &peer.Peer{AuthInfo: authInfo}), req)
+		}))
+	registryServer.Register(server)
+	go func() { _ = server.Serve(ln) }()
+	defer server.Stop()
+
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	nsClient := next.NewNetworkServiceRegistryClient(
+		grpcmetadata.NewNetworkServiceRegistryClient(),
+		registry.NewNetworkServiceRegistryClient(cc),
+	)
+	nseClient := next.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(cc),
+	)
+
+	// The path of the endpoint registrations carries the JWT of the registering client
+	pathToken, _, err := sandbox.GenerateTestToken(nil)
+	require.NoError(t, err)
+	pathCtx := grpcmetadata.PathWithContext(context.Background(), &grpcmetadata.Path{
+		PathSegments: []*grpcmetadata.PathSegment{{Token: pathToken}},
+	})
+
+	_, err = nsClient.Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.Error(t, err)
+
+	nse := &registry.NetworkServiceEndpoint{Name: "nse-1", NetworkServiceNames: []string{"ns-1"}}
+	_, err = nseClient.Register(pathCtx, nse.Clone())
+	require.NoError(t, err)
+	_, err = nseClient.Register(pathCtx, nse.Clone())
+	require.NoError(t, err)
+	_, err = nseClient.Unregister(pathCtx, nse.Clone())
+	require.NoError(t, err)
+
+	// The expire chain element unregisters the endpoint earlier by the timeout of its registration request
+	requestCtx, requestCancel := context.WithTimeout(pathCtx, time.Second)
+	defer requestCancel()
+	nse.Name = "nse-2"
+	nse.ExpirationTime = timestamppb.New(time.Now().Add(1500 * time.Millisecond))
+	_, err = nseClient.Register(requestCtx, nse.Clone())
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return len(buf.records(t)) == 6 }, 5*time.Second, 10*time.Millisecond)
+	records := buf.records(t)
+
+	require.Equal(t, audit.Denied, records[0].Event)
+	require.Equal(t, "ns", records[0].Resource)
+	require.Equal(t, "Register", records[0].Method)
+	require.Equal(t, peerID.String(), records[0].PeerSpiffeID)
+
+	var events []audit.Event
+	for _, record := range records[1:] {
+		events = append(events, record.Event)
+	}
+	require.Equal(t, []audit.Event{audit.Register, audit.Refresh, audit.Unregister, audit.Register, audit.Expire}, events)
+	require.Equal(t, "spiffe://test.com/subject", records[1].PathSpiffeID)
+	require.Equal(t, peerID.String(), records[1].PeerSpiffeID)
+	require.Equal(t, "nse-2", records[5].Name)
+}
+
+func TestAuditor_ForceUnregister(t *testing.T) {
+	adminID := spiffeid.RequireFromString("spiffe://test.com/admin")
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{adminID.URL()}}},
+	}}})
+
+	buf := new(syncBuffer)
+	audit.New(buf).ForceUnregister(ctx, "nse", "nse-1")
+
+	records := buf.records(t)
+	require.Len(t, records, 1)
+	require.Equal(t, audit.ForceUnregister, records[0].Event)
+	require.Equal(t, "nse", records[0].Resource)
+	require.Equal(t, "nse-1", records[0].Name)
+	require.Equal(t, adminID.String(), records[0].PeerSpiffeID)
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	f, err := audit.OpenRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, line := range []string{"record-1\n", "record-2\n", "record-3\n", "record-4\n"} {
+		_, err = f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	for file, expected := range map[string]string{
+		path:        "record-4\n",
+		path + ".1": "record-3\n",
+		path + ".2": "record-2\n",
+	} {
+		data, readErr := os.ReadFile(filepath.Clean(file))
+		require.NoError(t, readErr)
+		require.Equal(t, expected, string(data))
+	}
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+}
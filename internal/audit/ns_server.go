@@ -0,0 +1,57 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type auditNSServer struct {
+	auditor *Auditor
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer chain element that audits the network
+// service requests. It should be placed before the authorization to audit the denials.
+func NewNetworkServiceRegistryServer(a *Auditor) registry.NetworkServiceRegistryServer {
+	return &auditNSServer{
+		auditor: a,
+	}
+}
+
+func (s *auditNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	record := newRecord(ctx, nsResource, ns.GetName())
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	s.auditor.request(ctx, record, "Register", err)
+	return resp, err
+}
+
+func (s *auditNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *auditNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	record := newRecord(ctx, nsResource, ns.GetName())
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	s.auditor.request(ctx, record, "Unregister", err)
+	return resp, err
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/expiry"
+)
+
+type auditNSEServer struct {
+	auditor *Auditor
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element that
+// audits the network service endpoint requests. It should be placed before the authorization to audit the denials.
+func NewNetworkServiceEndpointRegistryServer(a *Auditor) registry.NetworkServiceEndpointRegistryServer {
+	return &auditNSEServer{
+		auditor: a,
+	}
+}
+
+func (s *auditNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	record := newRecord(ctx, nseResource, nse.GetName())
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	s.auditor.request(ctx, record, "Register", err)
+	return resp, err
+}
+
+func (s *auditNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *auditNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	record := newRecord(ctx, nseResource, nse.GetName())
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	s.auditor.request(ctx, record, "Unregister", err)
+	return resp, err
+}
+
+type expirationNSEServer struct {
+	auditor *Auditor
+}
+
+// NewExpirationNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element
+// that audits the expirations of the network service endpoints. It should be placed after the expire chain element
+// to observe expirations.
+func NewExpirationNetworkServiceEndpointRegistryServer(a *Auditor) registry.NetworkServiceEndpointRegistryServer {
+	return &expirationNSEServer{
+		auditor: a,
+	}
+}
+
+func (s *expirationNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *expirationNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *expirationNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	if expiry.IsExpired(ctx) {
+		s.auditor.expire(ctx, nseResource, nse.GetName())
+	}
+	return resp, nil
+}
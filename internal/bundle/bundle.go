@@ -0,0 +1,301 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle provides export and import of the registry contents as YAML or JSON bundles
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Format is the encoding of the bundle
+type Format string
+
+const (
+	// YAML encodes the bundle as YAML
+	YAML Format = "yaml"
+	// JSON encodes the bundle as JSON
+	JSON Format = "json"
+)
+
+// FormatFromPath returns the format matching the file extension, YAML is used by default
+func FormatFromPath(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return JSON
+	}
+	return YAML
+}
+
+// Bundle is a set of registry entries
+type Bundle struct {
+	NetworkServices         []*registry.NetworkService
+	NetworkServiceEndpoints []*registry.NetworkServiceEndpoint
+}
+
+type encodedBundle struct {
+	NetworkServices         []json.RawMessage `json:"networkServices,omitempty"`
+	NetworkServiceEndpoints []json.RawMessage `json:"networkServiceEndpoints,omitempty"`
+}
+
+// Marshal encodes the bundle in the format
+func Marshal(b *Bundle, format Format) ([]byte, error) {
+	encoded := new(encodedBundle)
+	for _, ns := range b.NetworkServices {
+		data, err := protojson.Marshal(ns)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal network service %s", ns.GetName())
+		}
+		encoded.NetworkServices = append(encoded.NetworkServices, data)
+	}
+	for _, nse := range b.NetworkServiceEndpoints {
+		data, err := protojson.Marshal(nse)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal network service endpoint %s", nse.GetName())
+		}
+		encoded.NetworkServiceEndpoints = append(encoded.NetworkServiceEndpoints, data)
+	}
+
+	data, err := json.MarshalIndent(encoded, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal bundle")
+	}
+	if format == JSON {
+		return data, nil
+	}
+	data, err = yaml.JSONToYAML(data)
+	return data, errors.Wrap(err, "failed to convert bundle to YAML")
+}
+
+// Unmarshal decodes the bundle in the format
+func Unmarshal(data []byte, format Format) (*Bundle, error) {
+	if format == YAML {
+		var err error
+		if data, err = yaml.YAMLToJSON(data); err != nil {
+			return nil, errors.Wrap(err, "failed to convert bundle from YAML")
+		}
+	}
+	encoded := new(encodedBundle)
+	if err := json.Unmarshal(data, encoded); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal bundle")
+	}
+
+	b := new(Bundle)
+	for _, data := range encoded.NetworkServices {
+		ns := new(registry.NetworkService)
+		if err := protojson.Unmarshal(data, ns); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal network service")
+		}
+		b.NetworkServices = append(b.NetworkServices, ns)
+	}
+	for _, data := range encoded.NetworkServiceEndpoints {
+		nse := new(registry.NetworkServiceEndpoint)
+		if err := protojson.Unmarshal(data, nse); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal network service endpoint")
+		}
+		b.NetworkServiceEndpoints = append(b.NetworkServiceEndpoints, nse)
+	}
+	return b, nil
+}
+
+// Export returns all entries found by the registry clients
+func Export(ctx context.Context, nsClient registry.NetworkServiceRegistryClient, nseClient registry.NetworkServiceEndpointRegistryClient) (*Bundle, error) {
+	nsStream, err := nsClient.Find(ctx, &registry.NetworkServiceQuery{NetworkService: new(registry.NetworkService)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find network services")
+	}
+	nseStream, err := nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find network service endpoints")
+	}
+
+	b := &Bundle{
+		NetworkServices:         registry.ReadNetworkServiceList(nsStream),
+		NetworkServiceEndpoints: registry.ReadNetworkServiceEndpointList(nseStream),
+	}
+	sort.Slice(b.NetworkServices, func(i, j int) bool {
+		return b.NetworkServices[i].GetName() < b.NetworkServices[j].GetName()
+	})
+	sort.Slice(b.NetworkServiceEndpoints, func(i, j int) bool {
+		return b.NetworkServiceEndpoints[i].GetName() < b.NetworkServiceEndpoints[j].GetName()
+	})
+	return b, nil
+}
+
+// Import registers the entries of the bundle with the registry clients. The registry assigns the paths of the
+// entries, so those stored in the bundle are ignored. The expiration and the initial registration times of the
+// endpoints are ignored as well unless they are kept by WithTimes. The entries missing from the bundle are kept unless
+// they are pruned by WithPrune.
+func Import(ctx context.Context, b *Bundle, nsClient registry.NetworkServiceRegistryClient, nseClient registry.NetworkServiceEndpointRegistryClient,
+	options ...Option) error {
+	o := newImportOptions(options)
+	for _, ns := range b.NetworkServices {
+		if _, err := nsClient.Register(ctx, cleanNetworkService(ns)); err != nil {
+			return errors.Wrapf(err, "failed to register network service %s", ns.GetName())
+		}
+	}
+	for _, nse := range b.NetworkServiceEndpoints {
+		registration := cleanNetworkServiceEndpoint(nse)
+		if o.keepTimes {
+			registration.ExpirationTime = nse.GetExpirationTime()
+			registration.InitialRegistrationTime = nse.GetInitialRegistrationTime()
+		}
+		if _, err := nseClient.Register(ctx, registration); err != nil {
+			return errors.Wrapf(err, "failed to register network service endpoint %s", nse.GetName())
+		}
+	}
+	if !o.prune {
+		return nil
+	}
+
+	current, err := Export(ctx, nsClient, nseClient)
+	if err != nil {
+		return err
+	}
+	// The endpoints are unregistered before the network services they refer to
+	nss, nses := missing(current, b)
+	for _, nse := range nses {
+		if _, err := nseClient.Unregister(ctx, nse); err != nil {
+			return errors.Wrapf(err, "failed to unregister network service endpoint %s", nse.GetName())
+		}
+	}
+	for _, ns := range nss {
+		if _, err := nsClient.Unregister(ctx, ns); err != nil {
+			return errors.Wrapf(err, "failed to unregister network service %s", ns.GetName())
+		}
+	}
+	return nil
+}
+
+// missing returns the current entries missing from the desired bundle
+func missing(current, desired *Bundle) (nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) {
+	desiredNames := make(map[string]bool)
+	for _, ns := range desired.NetworkServices {
+		desiredNames["ns/"+ns.GetName()] = true
+	}
+	for _, nse := range desired.NetworkServiceEndpoints {
+		desiredNames["nse/"+nse.GetName()] = true
+	}
+	for _, ns := range current.NetworkServices {
+		if !desiredNames["ns/"+ns.GetName()] {
+			nss = append(nss, ns)
+		}
+	}
+	for _, nse := range current.NetworkServiceEndpoints {
+		if !desiredNames["nse/"+nse.GetName()] {
+			nses = append(nses, nse)
+		}
+	}
+	return nss, nses
+}
+
+// Op is the change import makes to the registry entry
+type Op string
+
+const (
+	// Add is the registration of a new entry
+	Add Op = "+"
+	// Update is the registration of an entry changing the stored one
+	Update Op = "~"
+	// Remove is the unregistration of an entry missing from the bundle
+	Remove Op = "-"
+)
+
+// Change is the change of the registry entry
+type Change struct {
+	Op   Op
+	Kind string
+	Name string
+}
+
+func (c *Change) String() string {
+	return string(c.Op) + " " + c.Kind + " " + c.Name
+}
+
+// Diff returns the changes importing the desired bundle with the options makes to the registry with the current
+// entries. The fields assigned by the registry are not compared.
+func Diff(current, desired *Bundle, options ...Option) []*Change {
+	currentNSs := make(map[string]proto.Message, len(current.NetworkServices))
+	for _, ns := range current.NetworkServices {
+		currentNSs[ns.GetName()] = cleanNetworkService(ns)
+	}
+	currentNSEs := make(map[string]proto.Message, len(current.NetworkServiceEndpoints))
+	for _, nse := range current.NetworkServiceEndpoints {
+		currentNSEs[nse.GetName()] = cleanNetworkServiceEndpoint(nse)
+	}
+
+	var changes []*Change
+	for _, ns := range desired.NetworkServices {
+		if op, changed := diff(currentNSs, ns.GetName(), cleanNetworkService(ns)); changed {
+			changes = append(changes, &Change{Op: op, Kind: "ns", Name: ns.GetName()})
+		}
+	}
+	for _, nse := range desired.NetworkServiceEndpoints {
+		if op, changed := diff(currentNSEs, nse.GetName(), cleanNetworkServiceEndpoint(nse)); changed {
+			changes = append(changes, &Change{Op: op, Kind: "nse", Name: nse.GetName()})
+		}
+	}
+	if !newImportOptions(options).prune {
+		return changes
+	}
+
+	nss, nses := missing(current, desired)
+	for _, nse := range nses {
+		changes = append(changes, &Change{Op: Remove, Kind: "nse", Name: nse.GetName()})
+	}
+	for _, ns := range nss {
+		changes = append(changes, &Change{Op: Remove, Kind: "ns", Name: ns.GetName()})
+	}
+	return changes
+}
+
+func diff(current map[string]proto.Message, name string, desired proto.Message) (Op, bool) {
+	stored, ok := current[name]
+	switch {
+	case !ok:
+		return Add, true
+	case !proto.Equal(stored, desired):
+		return Update, true
+	default:
+		return "", false
+	}
+}
+
+// cleanNetworkService removes the fields assigned by the registry
+func cleanNetworkService(ns *registry.NetworkService) *registry.NetworkService {
+	ns = ns.Clone()
+	ns.PathIds = nil
+	return ns
+}
+
+// cleanNetworkServiceEndpoint removes the fields assigned by the registry
+func cleanNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	nse = nse.Clone()
+	nse.PathIds = nil
+	nse.ExpirationTime = nil
+	nse.InitialRegistrationTime = nil
+	return nse
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/bundle"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+func TestMarshal(t *testing.T) {
+	b := &bundle.Bundle{
+		NetworkServices: []*registry.NetworkService{{Name: "ns-1", Payload: "IP"}},
+		NetworkServiceEndpoints: []*registry.NetworkServiceEndpoint{{
+			Name:                "nse-1",
+			NetworkServiceNames: []string{"ns-1"},
+			Url:                 "tcp://1.1.1.1:5000",
+		}},
+	}
+	for _, format := range []bundle.Format{bundle.YAML, bundle.JSON} {
+		data, err := bundle.Marshal(b, format)
+		require.NoError(t, err)
+		decoded, err := bundle.Unmarshal(data, format)
+		require.NoError(t, err)
+		require.Empty(t, bundle.Diff(b, decoded))
+		require.Empty(t, bundle.Diff(decoded, b))
+	}
+
+	decoded, err := bundle.Unmarshal([]byte(`
+networkServices:
+- name: ns-2
+  payload: ETHERNET
+`), bundle.FormatFromPath("bundle.yml"))
+	require.NoError(t, err)
+	require.Equal(t, "ETHERNET", decoded.NetworkServices[0].GetPayload())
+}
+
+func TestExportImport(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	registryServer := registryserver.NewServer(ctx, sandbox.GenerateTestToken)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	registryServer.Register(server)
+	go func() { _ = server.Serve(ln) }()
+	defer server.Stop()
+
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	nsClient := next.NewNetworkServiceRegistryClient(
+		grpcmetadata.NewNetworkServiceRegistryClient(),
+		registry.NewNetworkServiceRegistryClient(cc),
+	)
+	nseClient := next.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(cc),
+	)
+
+	_, err = nsClient.Register(ctx, &registry.NetworkService{Name: "ns-1", Payload: "IP"})
+	require.NoError(t, err)
+	_, err = nsClient.Register(ctx, &registry.NetworkService{Name: "ns-3", Payload: "IP"})
+	require.NoError(t, err)
+	_, err = nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-2",
+		NetworkServiceNames: []string{"ns-3"},
+		Url:                 "tcp://2.2.2.2:5000",
+	})
+	require.NoError(t, err)
+
+	desired := &bundle.Bundle{
+		NetworkServices: []*registry.NetworkService{
+			{Name: "ns-1", Payload: "ETHERNET"},
+			{Name: "ns-2", Payload: "IP"},
+		},
+		NetworkServiceEndpoints: []*registry.NetworkServiceEndpoint{{
+			Name:                    "nse-1",
+			NetworkServiceNames:     []string{"ns-2"},
+			Url:                     "tcp://1.1.1.1:5000",
+			ExpirationTime:          timestamppb.New(time.Now().Add(10 * time.Minute)),
+			InitialRegistrationTime: timestamppb.New(time.Now().Add(-24 * time.Hour)),
+		}},
+	}
+
+	current, err := bundle.Export(ctx, nsClient, nseClient)
+	require.NoError(t, err)
+	require.Equal(t, []*bundle.Change{
+		{Op: bundle.Update, Kind: "ns", Name: "ns-1"},
+		{Op: bundle.Add, Kind: "ns", Name: "ns-2"},
+		{Op: bundle.Add, Kind: "nse", Name: "nse-1"},
+	}, bundle.Diff(current, desired))
+
+	// The entries missing from the bundle are removed by the pruning import only
+	require.Equal(t, []*bundle.Change{
+		{Op: bundle.Update, Kind: "ns", Name: "ns-1"},
+		{Op: bundle.Add, Kind: "ns", Name: "ns-2"},
+		{Op: bundle.Add, Kind: "nse", Name: "nse-1"},
+		{Op: bundle.Remove, Kind: "nse", Name: "nse-2"},
+		{Op: bundle.Remove, Kind: "ns", Name: "ns-3"},
+	}, bundle.Diff(current, desired, bundle.WithPrune()))
+
+	require.NoError(t, bundle.Import(ctx, desired, nsClient, nseClient, bundle.WithTimes(), bundle.WithPrune()))
+
+	current, err = bundle.Export(ctx, nsClient, nseClient)
+	require.NoError(t, err)
+	require.Len(t, current.NetworkServices, 2)
+	require.Len(t, current.NetworkServiceEndpoints, 1)
+	require.Empty(t, bundle.Diff(current, desired, bundle.WithPrune()))
+
+	// The times of the endpoints are kept
+	nse := current.NetworkServiceEndpoints[0]
+	require.True(t, proto.Equal(desired.NetworkServiceEndpoints[0].GetExpirationTime(), nse.GetExpirationTime()))
+	require.True(t, proto.Equal(desired.NetworkServiceEndpoints[0].GetInitialRegistrationTime(), nse.GetInitialRegistrationTime()))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+type importOptions struct {
+	keepTimes bool
+	prune     bool
+}
+
+// Option modifies the import option value
+type Option func(o *importOptions)
+
+// WithTimes keeps the expiration and the initial registration times of the network service endpoints stored in the
+// bundle, the registry still limits the expiration times
+func WithTimes() Option {
+	return func(o *importOptions) {
+		o.keepTimes = true
+	}
+}
+
+// WithPrune unregisters the entries missing from the bundle, so the registry keeps only the entries of the bundle
+func WithPrune() Option {
+	return func(o *importOptions) {
+		o.prune = true
+	}
+}
+
+func newImportOptions(options []Option) *importOptions {
+	o := new(importOptions)
+	for _, opt := range options {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package catalog provides the static network services loaded from the directory of manifests. The network services
+// are kept in the registry while their manifests exist and can't be changed or unregistered by the clients.
+package catalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Registry is a registry keeping the network services of the catalog
+type Registry interface {
+	// Restore stores network services and network service endpoints in the registry
+	Restore(ctx context.Context, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error
+	// Remove deletes network services and network service endpoints from the registry
+	Remove(ctx context.Context, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error
+}
+
+// Catalog is the set of network services loaded from the directory of manifests
+type Catalog struct {
+	dir      string
+	mu       sync.RWMutex
+	services map[string]*registry.NetworkService
+}
+
+// New creates the catalog of the network services from the manifests in the directory
+func New(dir string) *Catalog {
+	return &Catalog{
+		dir:      dir,
+		services: make(map[string]*registry.NetworkService),
+	}
+}
+
+// Contains returns true if the network service is in the catalog
+func (c *Catalog) Contains(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.services[name]
+	return ok
+}
+
+// Get returns the network service of the catalog defined by its manifest
+func (c *Catalog) Get(name string) (*registry.NetworkService, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ns, ok := c.services[name]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(ns).(*registry.NetworkService), true
+}
+
+// Load loads the manifests and applies them to the registry. The network services whose manifests were removed since
+// the previous load are removed from the registry.
+func (c *Catalog) Load(ctx context.Context, r Registry) error {
+	services, err := c.read()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var changed, removed []*registry.NetworkService
+	for name, ns := range services {
+		if stored, ok := c.services[name]; !ok || !proto.Equal(stored, ns) {
+			changed = append(changed, ns)
+		}
+	}
+	for name, ns := range c.services {
+		if _, ok := services[name]; !ok {
+			removed = append(removed, ns)
+		}
+	}
+
+	if err := r.Restore(ctx, changed, nil); err != nil {
+		return errors.Wrap(err, "failed to apply catalog")
+	}
+	// The removed network services are not protected anymore even if their removal fails
+	c.services = services
+	if err := r.Remove(ctx, removed, nil); err != nil {
+		return errors.Wrap(err, "failed to remove network services dropped from catalog")
+	}
+
+	if len(changed) > 0 || len(removed) > 0 {
+		log.FromContext(ctx).Infof("catalog applied: %d network services changed, %d removed", len(changed), len(removed))
+	}
+	return nil
+}
+
+// Run reloads the manifests on changes of the directory until the context is done
+func (c *Catalog) Run(ctx context.Context, r Registry) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to watch catalog: %s", err.Error())
+		return
+	}
+	defer func() { _ = watcher.Close() }()
+
+	if err := watcher.Add(c.dir); err != nil {
+		log.FromContext(ctx).Errorf("failed to watch catalog %s: %s", c.dir, err.Error())
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-watcher.Errors:
+			log.FromContext(ctx).Warnf("catalog watch error: %s", err.Error())
+		case <-watcher.Events:
+			if err := c.Load(ctx, r); err != nil {
+				log.FromContext(ctx).Errorf("failed to reload catalog: %s", err.Error())
+			}
+		}
+	}
+}
+
+// read parses all manifests of the directory
+func (c *Catalog) read() (map[string]*registry.NetworkService, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read catalog %s", c.dir)
+	}
+
+	services := make(map[string]*registry.NetworkService)
+	for _, entry := range entries {
+		if !isManifest(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(c.dir, entry.Name())
+		if info, statErr := os.Stat(path); statErr != nil || info.IsDir() {
+			continue
+		}
+		ns, err := readManifest(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := services[ns.GetName()]; ok {
+			return nil, errors.Errorf("network service %s is defined by several manifests", ns.GetName())
+		}
+		services[ns.GetName()] = ns
+	}
+	return services, nil
+}
+
+func isManifest(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+func readManifest(path string) (*registry.NetworkService, error) {
+	// #nosec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read manifest %s", path)
+	}
+	if data, err = yaml.YAMLToJSON(data); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest %s", path)
+	}
+	ns := new(registry.NetworkService)
+	if err := protojson.Unmarshal(data, ns); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest %s", path)
+	}
+	if ns.GetName() == "" {
+		return nil, errors.Errorf("manifest %s has no network service name", path)
+	}
+	return ns, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/catalog"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+func payloads(ctx context.Context, t *testing.T, r *registryserver.Server) map[string]string {
+	nss, _, err := r.Dump(ctx)
+	require.NoError(t, err)
+	result := make(map[string]string)
+	for _, ns := range nss {
+		result[ns.GetName()] = ns.GetPayload()
+	}
+	return result
+}
+
+func TestCatalog(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ns-1.yaml"), []byte("name: ns-1\npayload: IP\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ns-2.json"), []byte(`{"name": "ns-2", "payload": "ETHERNET"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0o600))
+
+	c := catalog.New(dir)
+	r := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithNSFrontServers(catalog.NewNetworkServiceRegistryServer(c)))
+	require.NoError(t, c.Load(ctx, r))
+	require.Equal(t, map[string]string{"ns-1": "IP", "ns-2": "ETHERNET"}, payloads(ctx, t, r))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	r.Register(server)
+	go func() { _ = server.Serve(ln) }()
+	defer server.Stop()
+
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	nsClient := next.NewNetworkServiceRegistryClient(
+		grpcmetadata.NewNetworkServiceRegistryClient(),
+		registry.NewNetworkServiceRegistryClient(cc),
+	)
+
+	// The clients can't unregister the network services of the catalog
+	_, err = nsClient.Unregister(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = nsClient.Register(ctx, &registry.NetworkService{Name: "ns-3"})
+	require.NoError(t, err)
+	_, err = nsClient.Unregister(ctx, &registry.NetworkService{Name: "ns-3"})
+	require.NoError(t, err)
+
+	// The clients can't change the network services of the catalog, the manifests are applied over their registrations
+	_, err = nsClient.Register(ctx, &registry.NetworkService{
+		Name:    "ns-1",
+		Payload: "ETHERNET",
+		Matches: []*registry.Match{{SourceSelector: map[string]string{"app": "client"}}},
+	})
+	require.NoError(t, err)
+	nss, _, err := r.Dump(ctx)
+	require.NoError(t, err)
+	for _, ns := range nss {
+		if ns.GetName() == "ns-1" {
+			require.Equal(t, "IP", ns.GetPayload())
+			require.Empty(t, ns.GetMatches())
+		}
+	}
+	require.Equal(t, map[string]string{"ns-1": "IP", "ns-2": "ETHERNET"}, payloads(ctx, t, r))
+
+	// The changes of the manifests are re-applied
+	go c.Run(ctx, r)
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ns-1.yaml"), []byte("name: ns-1\npayload: ETHERNET\n"), 0o600))
+	require.NoError(t, os.Remove(filepath.Join(dir, "ns-2.json")))
+	require.Eventually(t, func() bool {
+		return len(payloads(ctx, t, r)) == 1 && payloads(ctx, t, r)["ns-1"] == "ETHERNET"
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = nsClient.Register(ctx, &registry.NetworkService{Name: "ns-2"})
+	require.NoError(t, err)
+	_, err = nsClient.Unregister(ctx, &registry.NetworkService{Name: "ns-2"})
+	require.NoError(t, err)
+}
+
+func TestCatalog_InvalidManifest(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ns.yaml"), []byte("payload: IP\n"), 0o600))
+
+	r := registryserver.NewServer(ctx, sandbox.GenerateTestToken)
+	require.Error(t, catalog.New(dir).Load(ctx, r))
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package catalog
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type catalogNSServer struct {
+	catalog *Catalog
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer chain element that registers the network
+// services from the catalog as defined by their manifests and rejects their unregistration
+func NewNetworkServiceRegistryServer(c *Catalog) registry.NetworkServiceRegistryServer {
+	return &catalogNSServer{
+		catalog: c,
+	}
+}
+
+func (s *catalogNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	// The clients, e.g. the endpoints registering the network services they provide, can register the network
+	// services of the catalog, but the manifests override their content
+	if manifest, ok := s.catalog.Get(ns.GetName()); ok {
+		ns.Payload = manifest.GetPayload()
+		ns.Matches = manifest.GetMatches()
+	}
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *catalogNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *catalogNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if s.catalog.Contains(ns.GetName()) {
+		return nil, status.Errorf(codes.PermissionDenied, "network service %s is defined by the static catalog", ns.GetName())
+	}
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
@@ -0,0 +1,463 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cluster provides replication of the registry storage between several registries through an embedded Raft
+// log. Only the leader commits mutations, followers forward them to the leader and serve reads locally.
+package cluster
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/raft"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/jsonrpc"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+)
+
+const (
+	leaderPollInterval = 50 * time.Millisecond
+	transportPoolSize  = 3
+	transportTimeout   = 10 * time.Second
+	retainSnapshots    = 2
+	raftDBFile         = "raft.db"
+)
+
+// Member is a registry participating in the cluster
+type Member struct {
+	// ID is the URL the other members reach the registry at
+	ID string
+	// Address is the raft address of the registry
+	Address string
+}
+
+// ParseMembers parses members formatted as <id>=<raft address>
+func ParseMembers(members []string) ([]Member, error) {
+	var result []Member
+	for _, m := range members {
+		i := strings.LastIndex(m, "=")
+		if i <= 0 || i == len(m)-1 {
+			return nil, errors.Errorf("invalid cluster member %q, expected <id>=<raft address>", m)
+		}
+		result = append(result, Member{ID: m[:i], Address: m[i+1:]})
+	}
+	return result, nil
+}
+
+// Node is a storage replicated to the other cluster members
+type Node struct {
+	ctx   context.Context
+	id    string
+	local storage.Storage
+	raft  *raft.Raft
+	trans *raft.NetworkTransport
+	store *boltStore
+
+	applyTimeout    time.Duration
+	expirePeriod    time.Duration
+	dataDir         string
+	listener        net.Listener
+	serverTLSConfig *tls.Config
+	clientTLSConfig *tls.Config
+	dialOptions     []grpc.DialOption
+	authorize       AuthorizeFunc
+
+	pending sync.Map
+
+	mu           sync.Mutex
+	conns        map[string]*grpc.ClientConn
+	nsListeners  []func(*registry.NetworkService, bool)
+	nseListeners []func(*registry.NetworkServiceEndpoint, bool)
+}
+
+// New starts the cluster node identified by id keeping the replicated entries in the local storage. The node
+// joins the cluster of members, which should include the node itself.
+func New(ctx context.Context, id string, members []Member, local storage.Storage, options ...Option) (*Node, error) {
+	n := &Node{
+		ctx:          ctx,
+		id:           id,
+		local:        local,
+		applyTimeout: defaultApplyTimeout,
+		expirePeriod: defaultExpirePeriod,
+		authorize: func(context.Context) error {
+			return errors.New("cluster peers are not authorized")
+		},
+		conns: make(map[string]*grpc.ClientConn),
+	}
+	for _, opt := range options {
+		opt(n)
+	}
+
+	configuration := raft.Configuration{}
+	var address string
+	for _, m := range members {
+		if m.ID == id {
+			address = m.Address
+		}
+		configuration.Servers = append(configuration.Servers, raft.Server{
+			Suffrage: raft.Voter,
+			ID:       raft.ServerID(m.ID),
+			Address:  raft.ServerAddress(m.Address),
+		})
+	}
+	if address == "" {
+		return nil, errors.Errorf("%s is not a cluster member", id)
+	}
+
+	if n.listener == nil {
+		var err error
+		if n.listener, err = net.Listen("tcp", address); err != nil {
+			return nil, errors.Wrapf(err, "failed to listen on %s", address)
+		}
+	}
+	n.trans = raft.NewNetworkTransport(newStreamLayer(n.listener, address, n.serverTLSConfig, n.clientTLSConfig),
+		transportPoolSize, transportTimeout, logrus.StandardLogger().Out)
+
+	config := raft.DefaultConfig()
+	config.LocalID = raft.ServerID(id)
+	config.Logger = hclog.New(&hclog.LoggerOptions{
+		Name:   "raft",
+		Level:  hclog.Info,
+		Output: logrus.StandardLogger().Out,
+	})
+
+	logStore, stableStore, snapshotStore, err := n.raftStores()
+	if err != nil {
+		_ = n.trans.Close()
+		return nil, err
+	}
+	fail := func(err error) (*Node, error) {
+		_ = n.trans.Close()
+		if n.store != nil {
+			_ = n.store.Close()
+		}
+		return nil, err
+	}
+	// The cluster is bootstrapped only by the nodes starting for the first time, the restarted nodes keep their state
+	hasState, err := raft.HasExistingState(logStore, stableStore, snapshotStore)
+	if err != nil {
+		return fail(errors.Wrap(err, "failed to read raft state"))
+	}
+	if !hasState {
+		if err = raft.BootstrapCluster(config, logStore, stableStore, snapshotStore, n.trans, configuration); err != nil {
+			return fail(errors.Wrap(err, "failed to bootstrap cluster"))
+		}
+	}
+	if n.raft, err = raft.NewRaft(config, &fsm{node: n}, logStore, stableStore, snapshotStore, n.trans); err != nil {
+		return fail(errors.Wrap(err, "failed to start raft"))
+	}
+
+	if n.expirePeriod > 0 {
+		go n.expire()
+	}
+
+	return n, nil
+}
+
+// raftStores returns the stores of the raft state kept in the data directory, or in memory if it is not set
+func (n *Node) raftStores() (raft.LogStore, raft.StableStore, raft.SnapshotStore, error) {
+	if n.dataDir == "" {
+		inmem := raft.NewInmemStore()
+		return inmem, inmem, raft.NewInmemSnapshotStore(), nil
+	}
+	if err := os.MkdirAll(n.dataDir, 0o700); err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "failed to create %s", n.dataDir)
+	}
+	snapshotStore, err := raft.NewFileSnapshotStore(n.dataDir, retainSnapshots, logrus.StandardLogger().Out)
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "failed to create snapshot store in %s", n.dataDir)
+	}
+	if n.store, err = newBoltStore(filepath.Join(n.dataDir, raftDBFile)); err != nil {
+		return nil, nil, nil, err
+	}
+	return n.store, n.store, snapshotStore, nil
+}
+
+// Register registers the service forwarding mutations to the leader
+func (n *Node) Register(s *grpc.Server) {
+	jsonrpc.Register(s, serviceName, jsonrpc.Method{Name: "Apply", Handler: (&applyServer{node: n}).Apply})
+}
+
+// IsLeader returns true if the node is the cluster leader
+func (n *Node) IsLeader() bool {
+	return n.raft.State() == raft.Leader
+}
+
+// Leader returns id of the cluster leader or an empty string if there is no leader
+func (n *Node) Leader() string {
+	_, id := n.raft.LeaderWithID()
+	return string(id)
+}
+
+// OnNetworkServiceChange implements storage.Notifier
+func (n *Node) OnNetworkServiceChange(f func(ns *registry.NetworkService, deleted bool)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.nsListeners = append(n.nsListeners, f)
+}
+
+// OnNetworkServiceEndpointChange implements storage.Notifier
+func (n *Node) OnNetworkServiceEndpointChange(f func(nse *registry.NetworkServiceEndpoint, deleted bool)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.nseListeners = append(n.nseListeners, f)
+}
+
+// NetworkServices implements storage.Storage
+func (n *Node) NetworkServices() ([]*registry.NetworkService, error) {
+	return n.local.NetworkServices()
+}
+
+// StoreNetworkService implements storage.Storage
+func (n *Node) StoreNetworkService(ns *registry.NetworkService) error {
+	data, err := protojson.Marshal(ns)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal network service %s", ns.GetName())
+	}
+	_, err = n.submit(&command{Op: opStore, Kind: kindNS, Name: ns.GetName(), Entry: data})
+	return err
+}
+
+// DeleteNetworkService implements storage.Storage
+func (n *Node) DeleteNetworkService(name string) (*registry.NetworkService, error) {
+	result, err := n.submit(&command{Op: opDelete, Kind: kindNS, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return result.ns, nil
+}
+
+// NetworkServiceEndpoints implements storage.Storage
+func (n *Node) NetworkServiceEndpoints() ([]*registry.NetworkServiceEndpoint, error) {
+	return n.local.NetworkServiceEndpoints()
+}
+
+// StoreNetworkServiceEndpoint implements storage.Storage
+func (n *Node) StoreNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint) error {
+	data, err := protojson.Marshal(nse)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal network service endpoint %s", nse.GetName())
+	}
+	_, err = n.submit(&command{Op: opStore, Kind: kindNSE, Name: nse.GetName(), Entry: data})
+	return err
+}
+
+// DeleteNetworkServiceEndpoint implements storage.Storage
+func (n *Node) DeleteNetworkServiceEndpoint(name string) (*registry.NetworkServiceEndpoint, error) {
+	result, err := n.submit(&command{Op: opDelete, Kind: kindNSE, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return result.nse, nil
+}
+
+// ExpireNetworkServiceEndpoint implements storage.Storage. Only the leader expires the endpoints, the expiration time
+// is compared with the committed one, so the endpoint refreshed through another node is kept. The followers leave
+// the expiration to the leader.
+func (n *Node) ExpireNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if !n.IsLeader() {
+		return nil, nil
+	}
+	data, err := protojson.Marshal(nse)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal network service endpoint %s", nse.GetName())
+	}
+	result, err := n.submit(&command{Op: opExpire, Kind: kindNSE, Name: nse.GetName(), Entry: data})
+	if err != nil {
+		return nil, err
+	}
+	return result.nse, nil
+}
+
+// Close leaves the cluster and closes the local storage
+func (n *Node) Close() error {
+	err := n.raft.Shutdown().Error()
+	if closeErr := n.trans.Close(); err == nil {
+		err = closeErr
+	}
+
+	n.mu.Lock()
+	for id, cc := range n.conns {
+		_ = cc.Close()
+		delete(n.conns, id)
+	}
+	n.mu.Unlock()
+
+	if n.store != nil {
+		if closeErr := n.store.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if closeErr := n.local.Close(); err == nil {
+		err = closeErr
+	}
+	return errors.Wrap(err, "failed to close cluster node")
+}
+
+// submit commits the command to the cluster and waits until it is applied by this node
+func (n *Node) submit(cmd *command) (*applyResult, error) {
+	cmd.ID = uuid.New().String()
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal command")
+	}
+
+	resultCh := make(chan *applyResult, 1)
+	n.pending.Store(cmd.ID, resultCh)
+	defer n.pending.Delete(cmd.ID)
+
+	ctx, cancel := context.WithTimeout(n.ctx, n.applyTimeout)
+	defer cancel()
+
+	if err = n.commit(ctx, data); err != nil {
+		return nil, err
+	}
+
+	select {
+	case result := <-resultCh:
+		return result, result.err
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "command is not applied")
+	}
+}
+
+// commit commits the command on the leader or forwards it to the leader
+func (n *Node) commit(ctx context.Context, data []byte) error {
+	for {
+		if n.IsLeader() {
+			err := n.raft.Apply(data, timeout(ctx)).Error()
+			if !errors.Is(err, raft.ErrNotLeader) && !errors.Is(err, raft.ErrLeadershipLost) {
+				return errors.Wrap(err, "failed to commit command")
+			}
+		} else if leader := n.Leader(); leader != "" {
+			err := n.forward(ctx, leader, data)
+			if status.Code(err) != codes.FailedPrecondition {
+				return errors.Wrapf(err, "failed to forward command to the leader %s", leader)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "no cluster leader")
+		case <-time.After(leaderPollInterval):
+		}
+	}
+}
+
+func (n *Node) forward(ctx context.Context, leader string, data []byte) error {
+	cc, err := n.clientConn(ctx, leader)
+	if err != nil {
+		return err
+	}
+	return jsonrpc.Invoke(ctx, cc, applyMethod, json.RawMessage(data), nil)
+}
+
+// clientConn returns the connection to the member. The member is dialed without holding the lock, so an unreachable
+// leader doesn't block the listeners notified by the applied commands.
+func (n *Node) clientConn(ctx context.Context, id string) (*grpc.ClientConn, error) {
+	n.mu.Lock()
+	cc, ok := n.conns[id]
+	n.mu.Unlock()
+	if ok {
+		return cc, nil
+	}
+
+	u, err := url.Parse(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cluster member id %s", id)
+	}
+	// nolint:staticcheck
+	cc, err = grpc.DialContext(ctx, grpcutils.URLToTarget(u), n.dialOptions...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial %s", id)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if existing, ok := n.conns[id]; ok {
+		// The member is dialed concurrently, the first connection is kept
+		_ = cc.Close()
+		return existing, nil
+	}
+	n.conns[id] = cc
+	return cc, nil
+}
+
+// expire removes the expired network service endpoints, e.g. registered through the followers or the crashed nodes
+func (n *Node) expire() {
+	logger := log.FromContext(n.ctx).WithField("cluster", "expire")
+
+	ticker := time.NewTicker(n.expirePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !n.IsLeader() {
+			continue
+		}
+		nses, err := n.local.NetworkServiceEndpoints()
+		if err != nil {
+			logger.Errorf("failed to list network service endpoints: %s", err.Error())
+			continue
+		}
+		now := time.Now()
+		for _, nse := range nses {
+			if nse.GetExpirationTime() == nil || nse.GetExpirationTime().AsTime().After(now) {
+				continue
+			}
+			data, err := expireCommand(nse)
+			if err != nil {
+				logger.Errorf("failed to marshal command: %s", err.Error())
+				continue
+			}
+			if err := n.raft.Apply(data, n.applyTimeout).Error(); err != nil {
+				logger.Errorf("failed to remove expired network service endpoint %s: %s", nse.GetName(), err.Error())
+			}
+		}
+	}
+}
+
+func timeout(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return 0
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/cluster"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+)
+
+const clusterSize = 3
+
+type member struct {
+	node *cluster.Node
+	url  *url.URL
+	cc   *grpc.ClientConn
+}
+
+func (m *member) nsClient() registry.NetworkServiceRegistryClient {
+	return next.NewNetworkServiceRegistryClient(
+		grpcmetadata.NewNetworkServiceRegistryClient(),
+		registry.NewNetworkServiceRegistryClient(m.cc),
+	)
+}
+
+func (m *member) nseClient() registry.NetworkServiceEndpointRegistryClient {
+	return next.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(m.cc),
+	)
+}
+
+func startCluster(ctx context.Context, t *testing.T, options ...cluster.Option) (leader *member, followers []*member) {
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+	}
+
+	var members []cluster.Member
+	var grpcListeners, raftListeners []net.Listener
+	for i := 0; i < clusterSize; i++ {
+		grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		raftListener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		grpcListeners = append(grpcListeners, grpcListener)
+		raftListeners = append(raftListeners, raftListener)
+		members = append(members, cluster.Member{
+			ID:      "tcp://" + grpcListener.Addr().String(),
+			Address: raftListener.Addr().String(),
+		})
+	}
+
+	var result []*member
+	for i := 0; i < clusterSize; i++ {
+		node, err := cluster.New(ctx, members[i].ID, members, storage.NewMemory(), append([]cluster.Option{
+			cluster.WithListener(raftListeners[i]),
+			cluster.WithDialOptions(dialOptions...),
+			cluster.WithAuthorize(func(context.Context) error { return nil }),
+			cluster.WithApplyTimeout(5 * time.Second),
+			cluster.WithExpirePeriod(100 * time.Millisecond),
+		}, options...)...)
+		require.NoError(t, err)
+
+		server := grpc.NewServer()
+		registryserver.NewServer(ctx, sandbox.GenerateTestToken, registryserver.WithStorage(node)).Register(server)
+		node.Register(server)
+		go func(ln net.Listener) { _ = server.Serve(ln) }(grpcListeners[i])
+
+		u, err := url.Parse(members[i].ID)
+		require.NoError(t, err)
+		cc, err := grpc.DialContext(ctx, u.Host, dialOptions...)
+		require.NoError(t, err)
+
+		m := &member{node: node, url: u, cc: cc}
+		t.Cleanup(func() {
+			_ = m.cc.Close()
+			server.Stop()
+			_ = m.node.Close()
+		})
+		result = append(result, m)
+	}
+
+	require.Eventually(t, func() bool {
+		for _, m := range result {
+			if m.node.Leader() == "" {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 50*time.Millisecond)
+
+	for _, m := range result {
+		if m.node.IsLeader() {
+			leader = m
+		} else {
+			followers = append(followers, m)
+		}
+	}
+	require.NotNil(t, leader)
+	return leader, followers
+}
+
+func findNSEs(ctx context.Context, t *testing.T, m *member, name string) []*registry.NetworkServiceEndpoint {
+	stream, err := m.nseClient().Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: name},
+	})
+	require.NoError(t, err)
+	return registry.ReadNetworkServiceEndpointList(stream)
+}
+
+func TestCluster_NetworkServiceRegistration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	leader, followers := startCluster(ctx, t)
+
+	for _, m := range append(followers, leader) {
+		name := fmt.Sprintf("ns-%s", m.url.Host)
+		_, err := m.nsClient().Register(ctx, &registry.NetworkService{Name: name})
+		require.NoError(t, err)
+
+		for _, other := range append(followers, leader) {
+			require.Eventually(t, func() bool {
+				stream, err := other.nsClient().Find(ctx, &registry.NetworkServiceQuery{
+					NetworkService: &registry.NetworkService{Name: name},
+				})
+				require.NoError(t, err)
+				return len(registry.ReadNetworkServiceList(stream)) == 1
+			}, time.Second, 10*time.Millisecond)
+		}
+
+		_, err = m.nsClient().Unregister(ctx, &registry.NetworkService{Name: name})
+		require.NoError(t, err)
+
+		for _, other := range append(followers, leader) {
+			require.Eventually(t, func() bool {
+				stream, err := other.nsClient().Find(ctx, &registry.NetworkServiceQuery{
+					NetworkService: &registry.NetworkService{Name: name},
+				})
+				require.NoError(t, err)
+				return len(registry.ReadNetworkServiceList(stream)) == 0
+			}, time.Second, 10*time.Millisecond)
+		}
+	}
+}
+
+func TestCluster_NetworkServiceEndpointRegistration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	leader, followers := startCluster(ctx, t)
+
+	watchCtx, cancelWatch := context.WithCancel(ctx)
+	defer cancelWatch()
+	watch, err := leader.nseClient().Find(watchCtx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+		Watch:                  true,
+	})
+	require.NoError(t, err)
+
+	result, err := followers[0].nseClient().Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		Url:                 "tcp://127.0.0.1",
+		NetworkServiceNames: []string{"ns-1"},
+	})
+	require.NoError(t, err)
+
+	resp, err := watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, result.GetName(), resp.GetNetworkServiceEndpoint().GetName())
+	require.False(t, resp.GetDeleted())
+
+	for _, m := range append(followers, leader) {
+		require.Eventually(t, func() bool {
+			return len(findNSEs(ctx, t, m, result.GetName())) == 1
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	_, err = followers[0].nseClient().Unregister(ctx, result)
+	require.NoError(t, err)
+
+	resp, err = watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, result.GetName(), resp.GetNetworkServiceEndpoint().GetName())
+	require.True(t, resp.GetDeleted())
+
+	for _, m := range append(followers, leader) {
+		require.Eventually(t, func() bool {
+			return len(findNSEs(ctx, t, m, result.GetName())) == 0
+		}, time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestCluster_NetworkServiceEndpointExpiration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	leader, followers := startCluster(ctx, t)
+
+	// The request deadline shortens the expiration, so the request is made without it
+	result, err := followers[0].nseClient().Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		Url:                 "tcp://127.0.0.1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(3 * time.Second)),
+	})
+	require.NoError(t, err)
+
+	for _, m := range append(followers, leader) {
+		require.Eventually(t, func() bool {
+			return len(findNSEs(ctx, t, m, result.GetName())) == 1
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	// Stop the registry serving the endpoint, so it is expired by the leader
+	require.NoError(t, followers[0].node.Close())
+
+	for _, m := range []*member{followers[1], leader} {
+		require.Eventually(t, func() bool {
+			return len(findNSEs(ctx, t, m, result.GetName())) == 0
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestCluster_NetworkServiceEndpointRefresh(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	leader, followers := startCluster(ctx, t)
+
+	// The endpoint registered through one node is refreshed through another one before it expires on the first one
+	for i, nodes := range [][2]*member{{leader, followers[0]}, {followers[0], followers[1]}, {followers[1], leader}} {
+		name := fmt.Sprintf("nse-%d", i)
+
+		// The expire chain element unregisters the endpoint earlier by the timeout of its registration request
+		requestCtx, requestCancel := context.WithTimeout(ctx, time.Second)
+		_, err := nodes[0].nseClient().Register(requestCtx, &registry.NetworkServiceEndpoint{
+			Name:                name,
+			Url:                 "tcp://127.0.0.1",
+			NetworkServiceNames: []string{"ns-1"},
+			ExpirationTime:      timestamppb.New(time.Now().Add(1500 * time.Millisecond)),
+		})
+		requestCancel()
+		require.NoError(t, err)
+		_, err = nodes[1].nseClient().Register(ctx, &registry.NetworkServiceEndpoint{
+			Name:                name,
+			Url:                 "tcp://127.0.0.1",
+			NetworkServiceNames: []string{"ns-1"},
+			ExpirationTime:      timestamppb.New(time.Now().Add(time.Hour)),
+		})
+		require.NoError(t, err)
+	}
+
+	require.Never(t, func() bool {
+		for _, m := range append(followers, leader) {
+			for i := 0; i < clusterSize; i++ {
+				if len(findNSEs(ctx, t, m, fmt.Sprintf("nse-%d", i))) == 0 {
+					return true
+				}
+			}
+		}
+		return false
+	}, 2*time.Second, 50*time.Millisecond)
+}
+
+func TestCluster_UnreachableLeader(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// The members can't be dialed until the end of the test
+	dialing := make(chan struct{}, clusterSize)
+	release := make(chan struct{})
+	defer close(release)
+	leader, followers := startCluster(ctx, t, cluster.WithApplyTimeout(time.Minute), cluster.WithDialOptions(
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			dialing <- struct{}{}
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			return new(net.Dialer).DialContext(ctx, "tcp", addr)
+		}),
+	))
+
+	changes := make(chan string, 1)
+	followers[0].node.OnNetworkServiceChange(func(ns *registry.NetworkService, _ bool) {
+		changes <- ns.GetName()
+	})
+
+	go func() { _ = followers[0].node.StoreNetworkService(&registry.NetworkService{Name: "ns-1"}) }()
+	select {
+	case <-dialing:
+	case <-ctx.Done():
+		require.FailNow(t, "the follower doesn't forward the mutation to the leader")
+	}
+
+	// The follower dialing the leader still applies the mutations committed by the leader
+	require.NoError(t, leader.node.StoreNetworkService(&registry.NetworkService{Name: "ns-2"}))
+	select {
+	case name := <-changes:
+		require.Equal(t, "ns-2", name)
+	case <-time.After(time.Second):
+		require.FailNow(t, "the follower doesn't apply the committed mutation")
+	}
+}
+
+func TestCluster_Restart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	dir := t.TempDir()
+	raftListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	members := []cluster.Member{{ID: "tcp://node", Address: raftListener.Addr().String()}}
+
+	start := func(ln net.Listener) *cluster.Node {
+		node, startErr := cluster.New(ctx, members[0].ID, members, storage.NewMemory(),
+			cluster.WithListener(ln),
+			cluster.WithAuthorize(func(context.Context) error { return nil }),
+			cluster.WithApplyTimeout(5*time.Second),
+			cluster.WithDataDir(dir),
+		)
+		require.NoError(t, startErr)
+		require.Eventually(t, node.IsLeader, 10*time.Second, 50*time.Millisecond)
+		return node
+	}
+
+	node := start(raftListener)
+	require.NoError(t, node.StoreNetworkService(&registry.NetworkService{Name: "ns-1", Payload: "IP"}))
+	require.NoError(t, node.Close())
+
+	// The restarted node keeps the raft state and replays the log to the new local storage
+	raftListener, err = net.Listen("tcp", members[0].Address)
+	require.NoError(t, err)
+	node = start(raftListener)
+	defer func() { _ = node.Close() }()
+	require.Eventually(t, func() bool {
+		nss, nssErr := node.NetworkServices()
+		require.NoError(t, nssErr)
+		return len(nss) == 1 && nss[0].GetName() == "ns-1"
+	}, 10*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/hashicorp/raft"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	opStore  = "store"
+	opDelete = "delete"
+	// opExpire deletes the network service endpoint only if it is not refreshed since it has expired
+	opExpire = "expire"
+
+	kindNS  = "ns"
+	kindNSE = "nse"
+)
+
+// command is a mutation of the storage replicated through the raft log
+type command struct {
+	// ID is set by the node submitting the command to wait for it to be applied
+	ID    string          `json:"id,omitempty"`
+	Op    string          `json:"op"`
+	Kind  string          `json:"kind"`
+	Name  string          `json:"name"`
+	Entry json.RawMessage `json:"entry,omitempty"`
+}
+
+type applyResult struct {
+	ns  *registry.NetworkService
+	nse *registry.NetworkServiceEndpoint
+	err error
+}
+
+func expireCommand(nse *registry.NetworkServiceEndpoint) ([]byte, error) {
+	data, err := protojson.Marshal(nse)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal network service endpoint %s", nse.GetName())
+	}
+	return json.Marshal(&command{Op: opExpire, Kind: kindNSE, Name: nse.GetName(), Entry: data})
+}
+
+// fsm applies the committed commands to the local storage of the node
+type fsm struct {
+	node *Node
+}
+
+func (f *fsm) Apply(l *raft.Log) interface{} {
+	cmd := new(command)
+	if err := json.Unmarshal(l.Data, cmd); err != nil {
+		log.FromContext(f.node.ctx).WithField("cluster", "Apply").Errorf("failed to unmarshal command: %s", err.Error())
+		return err
+	}
+
+	result := f.apply(cmd)
+	if resultCh, ok := f.node.pending.Load(cmd.ID); ok {
+		// The chain of the node submitted the command sends the events itself
+		select {
+		case resultCh.(chan *applyResult) <- result:
+		default:
+			// The command is committed twice after the leadership change
+		}
+		return result.err
+	}
+	if result.err == nil {
+		f.notify(cmd, result)
+	}
+	return result.err
+}
+
+func (f *fsm) apply(cmd *command) *applyResult {
+	local := f.node.local
+	result := new(applyResult)
+	switch cmd.Kind {
+	case kindNS:
+		switch cmd.Op {
+		case opStore:
+			result.ns = new(registry.NetworkService)
+			if result.err = protojson.Unmarshal(cmd.Entry, result.ns); result.err == nil {
+				result.err = local.StoreNetworkService(result.ns)
+			}
+		case opDelete:
+			result.ns, result.err = local.DeleteNetworkService(cmd.Name)
+		default:
+			result.err = errors.Errorf("unknown command: %q", cmd.Op)
+		}
+	case kindNSE:
+		switch cmd.Op {
+		case opStore:
+			result.nse = new(registry.NetworkServiceEndpoint)
+			if result.err = protojson.Unmarshal(cmd.Entry, result.nse); result.err == nil {
+				result.err = local.StoreNetworkServiceEndpoint(result.nse)
+			}
+		case opDelete:
+			result.nse, result.err = local.DeleteNetworkServiceEndpoint(cmd.Name)
+		case opExpire:
+			result.nse, result.err = f.expire(cmd)
+		default:
+			result.err = errors.Errorf("unknown command: %q", cmd.Op)
+		}
+	default:
+		result.err = errors.Errorf("unknown command kind: %q", cmd.Kind)
+	}
+	return result
+}
+
+func (f *fsm) expire(cmd *command) (*registry.NetworkServiceEndpoint, error) {
+	expired := new(registry.NetworkServiceEndpoint)
+	if err := protojson.Unmarshal(cmd.Entry, expired); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal network service endpoint")
+	}
+	nses, err := f.node.local.NetworkServiceEndpoints()
+	if err != nil {
+		return nil, err
+	}
+	for _, nse := range nses {
+		if nse.GetName() == cmd.Name && proto.Equal(nse.GetExpirationTime(), expired.GetExpirationTime()) {
+			return f.node.local.DeleteNetworkServiceEndpoint(cmd.Name)
+		}
+	}
+	return nil, nil
+}
+
+func (f *fsm) notify(cmd *command, result *applyResult) {
+	f.node.mu.Lock()
+	nsListeners, nseListeners := f.node.nsListeners, f.node.nseListeners
+	f.node.mu.Unlock()
+
+	deleted := cmd.Op != opStore
+	if result.ns != nil {
+		for _, listener := range nsListeners {
+			listener(result.ns, deleted)
+		}
+	}
+	if result.nse != nil {
+		for _, listener := range nseListeners {
+			listener(result.nse, deleted)
+		}
+	}
+}
+
+func (f *fsm) Snapshot() (raft.FSMSnapshot, error) {
+	nss, err := f.node.local.NetworkServices()
+	if err != nil {
+		return nil, err
+	}
+	nses, err := f.node.local.NetworkServiceEndpoints()
+	if err != nil {
+		return nil, err
+	}
+	return &fsmSnapshot{nss: nss, nses: nses}, nil
+}
+
+// Restore replaces the local storage content with the snapshot of the leader
+func (f *fsm) Restore(snapshot io.ReadCloser) error {
+	defer func() { _ = snapshot.Close() }()
+
+	nss, err := f.node.local.NetworkServices()
+	if err != nil {
+		return err
+	}
+	nses, err := f.node.local.NetworkServiceEndpoints()
+	if err != nil {
+		return err
+	}
+	staleNSs := make(map[string]bool)
+	for _, ns := range nss {
+		staleNSs[ns.GetName()] = true
+	}
+	staleNSEs := make(map[string]bool)
+	for _, nse := range nses {
+		staleNSEs[nse.GetName()] = true
+	}
+
+	decoder := json.NewDecoder(snapshot)
+	for decoder.More() {
+		cmd := new(command)
+		if err = decoder.Decode(cmd); err != nil {
+			return errors.Wrap(err, "failed to decode snapshot")
+		}
+		result := f.apply(cmd)
+		if result.err != nil {
+			return result.err
+		}
+		f.notify(cmd, result)
+		if cmd.Kind == kindNS {
+			delete(staleNSs, cmd.Name)
+		} else {
+			delete(staleNSEs, cmd.Name)
+		}
+	}
+
+	for name := range staleNSs {
+		cmd := &command{Op: opDelete, Kind: kindNS, Name: name}
+		result := f.apply(cmd)
+		if result.err != nil {
+			return result.err
+		}
+		f.notify(cmd, result)
+	}
+	for name := range staleNSEs {
+		cmd := &command{Op: opDelete, Kind: kindNSE, Name: name}
+		result := f.apply(cmd)
+		if result.err != nil {
+			return result.err
+		}
+		f.notify(cmd, result)
+	}
+	return nil
+}
+
+type fsmSnapshot struct {
+	nss  []*registry.NetworkService
+	nses []*registry.NetworkServiceEndpoint
+}
+
+func (s *fsmSnapshot) Persist(sink raft.SnapshotSink) error {
+	if err := s.write(sink); err != nil {
+		_ = sink.Cancel()
+		return err
+	}
+	return errors.Wrap(sink.Close(), "failed to close snapshot")
+}
+
+func (s *fsmSnapshot) write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, ns := range s.nss {
+		data, err := protojson.Marshal(ns)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal network service %s", ns.GetName())
+		}
+		if err = encoder.Encode(&command{Op: opStore, Kind: kindNS, Name: ns.GetName(), Entry: data}); err != nil {
+			return errors.Wrap(err, "failed to write snapshot")
+		}
+	}
+	for _, nse := range s.nses {
+		data, err := protojson.Marshal(nse)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal network service endpoint %s", nse.GetName())
+		}
+		if err = encoder.Encode(&command{Op: opStore, Kind: kindNSE, Name: nse.GetName(), Entry: data}); err != nil {
+			return errors.Wrap(err, "failed to write snapshot")
+		}
+	}
+	return nil
+}
+
+func (s *fsmSnapshot) Release() {}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultApplyTimeout = 10 * time.Second
+	defaultExpirePeriod = time.Second
+)
+
+// AuthorizeFunc checks whether the peer sending the request is allowed to replicate mutations through this node
+type AuthorizeFunc func(ctx context.Context) error
+
+// Option modifies the node option value
+type Option func(n *Node)
+
+// WithApplyTimeout sets how long the node waits for a mutation to be committed to the cluster
+func WithApplyTimeout(d time.Duration) Option {
+	return func(n *Node) {
+		n.applyTimeout = d
+	}
+}
+
+// WithExpirePeriod sets how often the leader removes the expired network service endpoints left by the followers, the
+// removal is disabled if it is 0, e.g. if the registry server already sweeps the expired endpoints on the leader
+func WithExpirePeriod(d time.Duration) Option {
+	return func(n *Node) {
+		n.expirePeriod = d
+	}
+}
+
+// WithDataDir sets the directory the node keeps the raft log and snapshots in, so the node keeps its state over
+// restarts. By default, the raft state is kept in memory and the restarted node rejoins the cluster as a new one.
+func WithDataDir(dir string) Option {
+	return func(n *Node) {
+		n.dataDir = dir
+	}
+}
+
+// WithListener sets the listener accepting the connections from the other cluster members. By default, the node
+// listens on its own raft address.
+func WithListener(ln net.Listener) Option {
+	return func(n *Node) {
+		n.listener = ln
+	}
+}
+
+// WithTLSConfig sets the configs securing the connections between the cluster members
+func WithTLSConfig(serverConfig, clientConfig *tls.Config) Option {
+	return func(n *Node) {
+		n.serverTLSConfig = serverConfig
+		n.clientTLSConfig = clientConfig
+	}
+}
+
+// WithDialOptions sets grpc.DialOptions used to forward mutations to the leader
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(n *Node) {
+		n.dialOptions = dialOptions
+	}
+}
+
+// WithAuthorize sets the check of the peers forwarding mutations to the leader. All peers are denied by default.
+func WithAuthorize(authorize AuthorizeFunc) Option {
+	return func(n *Node) {
+		n.authorize = authorize
+	}
+}
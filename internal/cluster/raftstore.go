@@ -0,0 +1,165 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	logsBucket   = []byte("logs")
+	stableBucket = []byte("stable")
+
+	// errKeyNotFound is the error raft expects from the stable store for the missing keys, it is checked by message
+	errKeyNotFound = errors.New("not found")
+)
+
+// boltStore is the raft log store and stable store keeping the raft state in the bbolt database file, so the node
+// keeps its term, vote and log over restarts
+type boltStore struct {
+	db *bolt.DB
+}
+
+func newBoltStore(path string) (*boltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{logsBucket, stableBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return errors.Wrapf(err, "failed to create bucket %s", bucket)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &boltStore{
+		db: db,
+	}, nil
+}
+
+func (s *boltStore) FirstIndex() (index uint64, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		if k, _ := tx.Bucket(logsBucket).Cursor().First(); k != nil {
+			index = binary.BigEndian.Uint64(k)
+		}
+		return nil
+	})
+	return index, err
+}
+
+func (s *boltStore) LastIndex() (index uint64, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		if k, _ := tx.Bucket(logsBucket).Cursor().Last(); k != nil {
+			index = binary.BigEndian.Uint64(k)
+		}
+		return nil
+	})
+	return index, err
+}
+
+func (s *boltStore) GetLog(index uint64, log *raft.Log) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(logsBucket).Get(uint64Key(index))
+		if data == nil {
+			return raft.ErrLogNotFound
+		}
+		return errors.Wrapf(json.Unmarshal(data, log), "failed to unmarshal raft log %d", index)
+	})
+}
+
+func (s *boltStore) StoreLog(log *raft.Log) error {
+	return s.StoreLogs([]*raft.Log{log})
+}
+
+func (s *boltStore) StoreLogs(logs []*raft.Log) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(logsBucket)
+		for _, log := range logs {
+			data, err := json.Marshal(log)
+			if err != nil {
+				return errors.Wrapf(err, "failed to marshal raft log %d", log.Index)
+			}
+			if err := b.Put(uint64Key(log.Index), data); err != nil {
+				return errors.Wrapf(err, "failed to store raft log %d", log.Index)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *boltStore) DeleteRange(min, max uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket(logsBucket).Cursor()
+		for k, _ := c.Seek(uint64Key(min)); k != nil && binary.BigEndian.Uint64(k) <= max; k, _ = c.Next() {
+			if err := c.Delete(); err != nil {
+				return errors.Wrapf(err, "failed to delete raft log %d", binary.BigEndian.Uint64(k))
+			}
+		}
+		return nil
+	})
+}
+
+func (s *boltStore) Set(key, val []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return errors.Wrapf(tx.Bucket(stableBucket).Put(key, val), "failed to store %s", key)
+	})
+}
+
+func (s *boltStore) Get(key []byte) (val []byte, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(stableBucket).Get(key)
+		if data == nil {
+			return errKeyNotFound
+		}
+		val = append([]byte(nil), data...)
+		return nil
+	})
+	return val, err
+}
+
+func (s *boltStore) SetUint64(key []byte, val uint64) error {
+	return s.Set(key, uint64Key(val))
+}
+
+func (s *boltStore) GetUint64(key []byte) (uint64, error) {
+	val, err := s.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(val), nil
+}
+
+func (s *boltStore) Close() error {
+	return errors.Wrap(s.db.Close(), "failed to close raft database")
+}
+
+func uint64Key(i uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, i)
+	return key
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+type address string
+
+func (a address) Network() string {
+	return "tcp"
+}
+
+func (a address) String() string {
+	return string(a)
+}
+
+// streamLayer carries the raft traffic between the cluster members, optionally secured with TLS
+type streamLayer struct {
+	net.Listener
+	address      address
+	clientConfig *tls.Config
+}
+
+func newStreamLayer(ln net.Listener, advertise string, serverConfig, clientConfig *tls.Config) *streamLayer {
+	if serverConfig != nil {
+		ln = tls.NewListener(ln, serverConfig)
+	}
+	return &streamLayer{
+		Listener:     ln,
+		address:      address(advertise),
+		clientConfig: clientConfig,
+	}
+}
+
+func (s *streamLayer) Addr() net.Addr {
+	return s.address
+}
+
+func (s *streamLayer) Dial(addr raft.ServerAddress, timeout time.Duration) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	if s.clientConfig == nil {
+		return dialer.Dial("tcp", string(addr))
+	}
+	return tls.DialWithDialer(dialer, "tcp", string(addr), s.clientConfig)
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package debughttp provides the read-only HTTP/JSON views of the registry contents for debugging
+package debughttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	// PathPrefix is the prefix of the paths of the views
+	PathPrefix = "/debug/registry"
+
+	timeout = 10 * time.Second
+)
+
+// Registry is a registry viewed by the handler
+type Registry interface {
+	// Dump returns all network services and network service endpoints stored by the registry
+	Dump(ctx context.Context) ([]*registry.NetworkService, []*registry.NetworkServiceEndpoint, error)
+}
+
+type networkServiceView struct {
+	Name    string   `json:"name"`
+	Payload string   `json:"payload,omitempty"`
+	PathIDs []string `json:"path_ids,omitempty"`
+}
+
+type networkServiceEndpointView struct {
+	Name                    string                       `json:"name"`
+	NetworkServiceNames     []string                     `json:"network_service_names,omitempty"`
+	Labels                  map[string]map[string]string `json:"labels,omitempty"`
+	URL                     string                       `json:"url,omitempty"`
+	PathIDs                 []string                     `json:"path_ids,omitempty"`
+	ExpirationTime          *time.Time                   `json:"expiration_time,omitempty"`
+	InitialRegistrationTime *time.Time                   `json:"initial_registration_time,omitempty"`
+	Expired                 bool                         `json:"expired"`
+}
+
+type registryView struct {
+	NetworkServices         []*networkServiceView         `json:"network_services"`
+	NetworkServiceEndpoints []*networkServiceEndpointView `json:"network_service_endpoints"`
+}
+
+// NewHandler returns the handler serving the views of the registry contents:
+//
+//	/debug/registry      - network services and network service endpoints
+//	/debug/registry/ns   - network services
+//	/debug/registry/nse  - network service endpoints
+//
+// The entries are filtered by the query parameters:
+//
+//	name=<substring>             - the entry name contains the substring
+//	path_id=<id>                 - the entry path contains the ID
+//	network_service=<name>       - the endpoint is registered for the network service
+//	url=<url>                    - the endpoint URL is equal to the URL
+//	label=<key>=<value>          - the endpoint has the label for any network service, may be repeated
+//	expired=<true|false>         - the endpoint is expired or not
+func NewHandler(r Registry) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathPrefix, serve(r, func(view *registryView) interface{} { return view }))
+	mux.HandleFunc(PathPrefix+"/ns", serve(r, func(view *registryView) interface{} { return view.NetworkServices }))
+	mux.HandleFunc(PathPrefix+"/nse", serve(r, func(view *registryView) interface{} { return view.NetworkServiceEndpoints }))
+	return mux
+}
+
+// ListenAndServe serves the views of the registry contents on the address until the context is done
+func ListenAndServe(ctx context.Context, listenOn string, r Registry) {
+	log.FromContext(ctx).Infof("Debug HTTP endpoint is enabled. Listening on %s", listenOn)
+	server := &http.Server{
+		Addr:         listenOn,
+		Handler:      NewHandler(r),
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.FromContext(ctx).Errorf("Failed to start debug HTTP endpoint: %s", err.Error())
+	}
+}
+
+func serve(r Registry, selectView func(*registryView) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "only GET is allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		f, err := parseFilter(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		nss, nses, err := r.Dump(req.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		view := &registryView{
+			NetworkServices:         []*networkServiceView{},
+			NetworkServiceEndpoints: []*networkServiceEndpointView{},
+		}
+		for _, ns := range nss {
+			if f.matchNetworkService(ns) {
+				view.NetworkServices = append(view.NetworkServices, newNetworkServiceView(ns))
+			}
+		}
+		now := time.Now()
+		for _, nse := range nses {
+			if f.matchNetworkServiceEndpoint(nse, now) {
+				view.NetworkServiceEndpoints = append(view.NetworkServiceEndpoints, newNetworkServiceEndpointView(nse, now))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(selectView(view)); err != nil {
+			log.FromContext(req.Context()).Errorf("failed to write debug view: %s", err.Error())
+		}
+	}
+}
+
+func newNetworkServiceView(ns *registry.NetworkService) *networkServiceView {
+	return &networkServiceView{
+		Name:    ns.GetName(),
+		Payload: ns.GetPayload(),
+		PathIDs: ns.GetPathIds(),
+	}
+}
+
+func newNetworkServiceEndpointView(nse *registry.NetworkServiceEndpoint, now time.Time) *networkServiceEndpointView {
+	view := &networkServiceEndpointView{
+		Name:                nse.GetName(),
+		NetworkServiceNames: nse.GetNetworkServiceNames(),
+		URL:                 nse.GetUrl(),
+		PathIDs:             nse.GetPathIds(),
+	}
+	if len(nse.GetNetworkServiceLabels()) > 0 {
+		view.Labels = make(map[string]map[string]string, len(nse.GetNetworkServiceLabels()))
+		for ns, labels := range nse.GetNetworkServiceLabels() {
+			view.Labels[ns] = labels.GetLabels()
+		}
+	}
+	if nse.GetExpirationTime() != nil {
+		expirationTime := nse.GetExpirationTime().AsTime()
+		view.ExpirationTime = &expirationTime
+		view.Expired = expirationTime.Before(now)
+	}
+	if nse.GetInitialRegistrationTime() != nil {
+		initialRegistrationTime := nse.GetInitialRegistrationTime().AsTime()
+		view.InitialRegistrationTime = &initialRegistrationTime
+	}
+	return view
+}
+
+type filter struct {
+	name           string
+	pathID         string
+	networkService string
+	url            string
+	labels         map[string]string
+	expired        *bool
+}
+
+func parseFilter(req *http.Request) (*filter, error) {
+	query := req.URL.Query()
+	f := &filter{
+		name:           query.Get("name"),
+		pathID:         query.Get("path_id"),
+		networkService: query.Get("network_service"),
+		url:            query.Get("url"),
+		labels:         make(map[string]string),
+	}
+	for _, label := range query["label"] {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			return nil, errors.Errorf("invalid label query parameter: %s", label)
+		}
+		f.labels[key] = value
+	}
+	if expired := query.Get("expired"); expired != "" {
+		v, err := strconv.ParseBool(expired)
+		if err != nil {
+			return nil, errors.Errorf("invalid expired query parameter: %s", expired)
+		}
+		f.expired = &v
+	}
+	return f, nil
+}
+
+func (f *filter) matchNetworkService(ns *registry.NetworkService) bool {
+	return strings.Contains(ns.GetName(), f.name) && matchPathID(ns.GetPathIds(), f.pathID)
+}
+
+func (f *filter) matchNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint, now time.Time) bool {
+	if !strings.Contains(nse.GetName(), f.name) || !matchPathID(nse.GetPathIds(), f.pathID) {
+		return false
+	}
+	if f.url != "" && nse.GetUrl() != f.url {
+		return false
+	}
+	if f.networkService != "" && !slices.Contains(nse.GetNetworkServiceNames(), f.networkService) {
+		return false
+	}
+	if f.expired != nil {
+		expired := nse.GetExpirationTime() != nil && nse.GetExpirationTime().AsTime().Before(now)
+		if expired != *f.expired {
+			return false
+		}
+	}
+	for key, value := range f.labels {
+		if !hasLabel(nse, key, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasLabel(nse *registry.NetworkServiceEndpoint, key, value string) bool {
+	for _, labels := range nse.GetNetworkServiceLabels() {
+		if v, ok := labels.GetLabels()[key]; ok && v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPathID(pathIDs []string, pathID string) bool {
+	return pathID == "" || slices.Contains(pathIDs, pathID)
+}
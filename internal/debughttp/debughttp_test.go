@@ -0,0 +1,107 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debughttp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/debughttp"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+func get(t *testing.T, server *httptest.Server, path string) []map[string]interface{} {
+	resp, err := http.Get(server.URL + path)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var entries []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&entries))
+	return entries
+}
+
+func names(entries []map[string]interface{}) []string {
+	var result []string
+	for _, entry := range entries {
+		result = append(result, entry["name"].(string))
+	}
+	return result
+}
+
+func TestHandler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	registryServer := registryserver.NewServer(ctx, sandbox.GenerateTestToken)
+	require.NoError(t, registryServer.Restore(context.Background(),
+		[]*registry.NetworkService{{Name: "ns-1", Payload: "IP"}, {Name: "ns-2", Payload: "ETHERNET"}},
+		[]*registry.NetworkServiceEndpoint{
+			{
+				Name:                "nse-1",
+				NetworkServiceNames: []string{"ns-1"},
+				NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+					"ns-1": {Labels: map[string]string{"app": "firewall"}},
+				},
+				Url:            "tcp://1.1.1.1:5000",
+				ExpirationTime: timestamppb.New(time.Now().Add(time.Hour)),
+			},
+			{
+				Name:                "nse-2",
+				NetworkServiceNames: []string{"ns-1", "ns-2"},
+				Url:                 "tcp://2.2.2.2:5000",
+				ExpirationTime:      timestamppb.New(time.Now().Add(time.Hour)),
+			},
+		}))
+
+	server := httptest.NewServer(debughttp.NewHandler(registryServer))
+	defer server.Close()
+
+	require.ElementsMatch(t, []string{"ns-1", "ns-2"}, names(get(t, server, "/debug/registry/ns")))
+	require.ElementsMatch(t, []string{"ns-2"}, names(get(t, server, "/debug/registry/ns?name=2")))
+
+	nses := get(t, server, "/debug/registry/nse?label=app=firewall")
+	require.Equal(t, []string{"nse-1"}, names(nses))
+	require.Equal(t, "tcp://1.1.1.1:5000", nses[0]["url"])
+	require.Equal(t, map[string]interface{}{"ns-1": map[string]interface{}{"app": "firewall"}}, nses[0]["labels"])
+	require.NotEmpty(t, nses[0]["expiration_time"])
+
+	require.Equal(t, []string{"nse-2"}, names(get(t, server, "/debug/registry/nse?network_service=ns-2")))
+	require.Equal(t, []string{"nse-2"}, names(get(t, server, "/debug/registry/nse?url=tcp://2.2.2.2:5000")))
+	require.Empty(t, get(t, server, "/debug/registry/nse?expired=true"))
+
+	resp, err := http.Get(server.URL + "/debug/registry/nse?expired=maybe")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/debug/registry", "application/json", http.NoBody)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
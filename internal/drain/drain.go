@@ -0,0 +1,119 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drain provides the registry chain elements draining the requests on the registry shutdown: new
+// registrations and watches are rejected, the running watches are ended and the in-flight requests are awaited.
+package drain
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errShuttingDown is returned to the clients of the draining registry, so they retry with another one
+var errShuttingDown = status.Error(codes.Unavailable, "registry is shutting down")
+
+// Drainer tracks the in-flight requests of the registry and drains them
+type Drainer struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	inFlight int
+	drained  chan struct{}
+}
+
+// New creates a new Drainer
+func New() *Drainer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Drainer{
+		ctx:     ctx,
+		cancel:  cancel,
+		drained: make(chan struct{}),
+	}
+}
+
+// Drain starts rejecting new registrations and watches and ends the running watches
+func (d *Drainer) Drain() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.ctx.Err() != nil {
+		return
+	}
+	d.cancel()
+	if d.inFlight == 0 {
+		close(d.drained)
+	}
+}
+
+// Draining returns true if the drain is started
+func (d *Drainer) Draining() bool {
+	return d.ctx.Err() != nil
+}
+
+// Wait waits for the in-flight requests to complete after the drain is started
+func (d *Drainer) Wait(ctx context.Context) error {
+	select {
+	case <-d.drained:
+		return nil
+	case <-ctx.Done():
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return errors.Wrapf(ctx.Err(), "%d requests are in flight", d.inFlight)
+	}
+}
+
+// start tracks the new request, it returns false if the request is rejected
+func (d *Drainer) start(reject bool) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if reject && d.ctx.Err() != nil {
+		return false
+	}
+	d.inFlight++
+	return true
+}
+
+// done completes the request tracked by start
+func (d *Drainer) done() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.inFlight--
+	if d.inFlight == 0 && d.ctx.Err() != nil {
+		select {
+		case <-d.drained:
+		default:
+			close(d.drained)
+		}
+	}
+}
+
+// watchContext returns the context of the watch which is cancelled on the drain start
+func (d *Drainer) watchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(d.ctx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/drain"
+)
+
+// blockingNSEServer blocks unregistrations until the channel is closed
+type blockingNSEServer struct {
+	unblockCh chan struct{}
+}
+
+func (s *blockingNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *blockingNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *blockingNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	<-s.unblockCh
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+func requireShuttingDown(t *testing.T, err error) {
+	require.Error(t, err)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestDrain(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	drainer := drain.New()
+	blocking := &blockingNSEServer{unblockCh: make(chan struct{})}
+	server := chain.NewNetworkServiceEndpointRegistryServer(
+		drain.NewNetworkServiceEndpointRegistryServer(drainer),
+		blocking,
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	nse := &registry.NetworkServiceEndpoint{Name: "nse-1", NetworkServiceNames: []string{"ns-1"}}
+	_, err := server.Register(ctx, nse.Clone())
+	require.NoError(t, err)
+
+	// Watch is ended by the drain
+	watchCh := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	watchErrCh := make(chan error, 1)
+	go func() {
+		watchErrCh <- server.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+			Watch:                  true,
+		}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, watchCh))
+	}()
+	require.Equal(t, "nse-1", (<-watchCh).GetNetworkServiceEndpoint().GetName())
+
+	// Unregister is in flight during the drain
+	unregisterErrCh := make(chan error, 1)
+	go func() {
+		_, unregisterErr := server.Unregister(ctx, nse.Clone())
+		unregisterErrCh <- unregisterErr
+	}()
+	require.Eventually(t, func() bool { return len(unregisterErrCh) == 0 }, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	require.False(t, drainer.Draining())
+	drainer.Drain()
+	require.True(t, drainer.Draining())
+	requireShuttingDown(t, <-watchErrCh)
+
+	// New registrations and watches are rejected, finds are served
+	_, err = server.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	requireShuttingDown(t, err)
+	requireShuttingDown(t, server.Find(&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+		Watch:                  true,
+	}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, make(chan *registry.NetworkServiceEndpointResponse, 10))))
+	findCh := make(chan *registry.NetworkServiceEndpointResponse, 10)
+	require.NoError(t, server.Find(&registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	}, streamchannel.NewNetworkServiceEndpointFindServer(ctx, findCh)))
+	require.Len(t, findCh, 1)
+
+	// In-flight requests are awaited
+	waitCtx, waitCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer waitCancel()
+	require.Error(t, drainer.Wait(waitCtx))
+
+	close(blocking.unblockCh)
+	require.NoError(t, <-unregisterErrCh)
+	require.NoError(t, drainer.Wait(ctx))
+}
+
+func TestDrain_Idle(t *testing.T) {
+	drainer := drain.New()
+	drainer.Drain()
+	drainer.Drain()
+	require.NoError(t, drainer.Wait(context.Background()))
+}
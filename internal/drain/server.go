@@ -0,0 +1,135 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drain
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// nsFindServer replaces the context of the find server, so the watch is ended on the drain start
+type nsFindServer struct {
+	ctx context.Context
+	registry.NetworkServiceRegistry_FindServer
+}
+
+func (s *nsFindServer) Context() context.Context {
+	return s.ctx
+}
+
+// nseFindServer replaces the context of the find server, so the watch is ended on the drain start
+type nseFindServer struct {
+	ctx context.Context
+	registry.NetworkServiceEndpointRegistry_FindServer
+}
+
+func (s *nseFindServer) Context() context.Context {
+	return s.ctx
+}
+
+type drainNSServer struct {
+	drainer *Drainer
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer chain element tracking the in-flight
+// network service requests and rejecting registrations and watches once the drain is started
+func NewNetworkServiceRegistryServer(d *Drainer) registry.NetworkServiceRegistryServer {
+	return &drainNSServer{
+		drainer: d,
+	}
+}
+
+func (s *drainNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if !s.drainer.start(true) {
+		return nil, errShuttingDown
+	}
+	defer s.drainer.done()
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *drainNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if !s.drainer.start(query.GetWatch()) {
+		return errShuttingDown
+	}
+	defer s.drainer.done()
+	if !query.GetWatch() {
+		return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+	}
+
+	ctx, cancel := s.drainer.watchContext(server.Context())
+	defer cancel()
+	err := next.NetworkServiceRegistryServer(ctx).Find(query, &nsFindServer{ctx: ctx, NetworkServiceRegistry_FindServer: server})
+	if s.drainer.Draining() && server.Context().Err() == nil {
+		return errShuttingDown
+	}
+	return err
+}
+
+func (s *drainNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	s.drainer.start(false)
+	defer s.drainer.done()
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+type drainNSEServer struct {
+	drainer *Drainer
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element tracking
+// the in-flight network service endpoint requests and rejecting registrations and watches once the drain is started
+func NewNetworkServiceEndpointRegistryServer(d *Drainer) registry.NetworkServiceEndpointRegistryServer {
+	return &drainNSEServer{
+		drainer: d,
+	}
+}
+
+func (s *drainNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if !s.drainer.start(true) {
+		return nil, errShuttingDown
+	}
+	defer s.drainer.done()
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *drainNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if !s.drainer.start(query.GetWatch()) {
+		return errShuttingDown
+	}
+	defer s.drainer.done()
+	if !query.GetWatch() {
+		return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+	}
+
+	ctx, cancel := s.drainer.watchContext(server.Context())
+	defer cancel()
+	err := next.NetworkServiceEndpointRegistryServer(ctx).Find(query, &nseFindServer{ctx: ctx, NetworkServiceEndpointRegistry_FindServer: server})
+	if s.drainer.Draining() && server.Context().Err() == nil {
+		return errShuttingDown
+	}
+	return err
+}
+
+func (s *drainNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	s.drainer.start(false)
+	defer s.drainer.done()
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package election provides election of the registry serving the clients among the registry replicas sharing a lease
+package election
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const defaultRenewInterval = time.Second
+
+// Lease is a lease held by the leader. Implementations should release the lease held by a crashed holder.
+type Lease interface {
+	// TryAcquire acquires or renews the lease for the holder if possible and returns the current holder
+	TryAcquire(ctx context.Context, holder string) (string, error)
+	// Release releases the lease if it is held by the holder
+	Release(ctx context.Context, holder string) error
+}
+
+// Option modifies the elector option value
+type Option func(e *Elector)
+
+// WithRenewInterval sets the interval between attempts to acquire or renew the lease
+func WithRenewInterval(d time.Duration) Option {
+	return func(e *Elector) {
+		e.renewInterval = d
+	}
+}
+
+// Elector competes for the lease with the other registries
+type Elector struct {
+	lease         Lease
+	id            string
+	renewInterval time.Duration
+
+	mu     sync.RWMutex
+	leader string
+}
+
+// New creates an elector competing for the lease on behalf of the registry identified by id. The id is the url
+// the clients are redirected to when the registry is the leader.
+func New(lease Lease, id string, options ...Option) *Elector {
+	e := &Elector{
+		lease:         lease,
+		id:            id,
+		renewInterval: defaultRenewInterval,
+	}
+	for _, opt := range options {
+		opt(e)
+	}
+	return e
+}
+
+// Run competes for the lease until the context is done, then releases the lease
+func (e *Elector) Run(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("election", "Run")
+
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.tryAcquire(ctx)
+		select {
+		case <-ctx.Done():
+			if err := e.lease.Release(context.Background(), e.id); err != nil {
+				logger.Errorf("failed to release lease: %s", err.Error())
+			}
+			e.setLeader("")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) tryAcquire(ctx context.Context) {
+	logger := log.FromContext(ctx).WithField("election", "tryAcquire")
+
+	leader, err := e.lease.TryAcquire(ctx, e.id)
+	if err != nil {
+		logger.Warnf("failed to acquire lease: %s", err.Error())
+		leader = ""
+	}
+	if previous := e.setLeader(leader); previous != leader {
+		logger.Infof("leader changed from %q to %q", previous, leader)
+	}
+}
+
+func (e *Elector) setLeader(leader string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	previous := e.leader
+	e.leader = leader
+	return previous
+}
+
+// Leader returns id of the current leader or an empty string if it is unknown
+func (e *Elector) Leader() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.leader
+}
+
+// IsLeader returns true if the registry holds the lease
+func (e *Elector) IsLeader() bool {
+	return e.Leader() == e.id
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package election_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/election"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+const renewInterval = 10 * time.Millisecond
+
+func TestFileLease(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "leader.lock")
+
+	first, second := election.NewFileLease(path), election.NewFileLease(path)
+
+	leader, err := first.TryAcquire(ctx, "tcp://first")
+	require.NoError(t, err)
+	require.Equal(t, "tcp://first", leader)
+
+	leader, err = second.TryAcquire(ctx, "tcp://second")
+	require.NoError(t, err)
+	require.Equal(t, "tcp://first", leader)
+
+	require.NoError(t, first.Release(ctx, "tcp://first"))
+
+	leader, err = second.TryAcquire(ctx, "tcp://second")
+	require.NoError(t, err)
+	require.Equal(t, "tcp://second", leader)
+
+	leader, err = first.TryAcquire(ctx, "tcp://first")
+	require.NoError(t, err)
+	require.Equal(t, "tcp://second", leader)
+
+	// The holder is replaced atomically next to the lock file, no temporary files are left
+	data, err := os.ReadFile(path + ".holder")
+	require.NoError(t, err)
+	require.Equal(t, "tcp://second", string(data))
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.NoError(t, second.Release(ctx, "tcp://second"))
+	_, err = os.Stat(path + ".holder")
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestFileLease_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "leader.lock")
+
+	holders := []string{"tcp://" + strings.Repeat("first.", 1000), "tcp://" + strings.Repeat("second.", 1000)}
+	leaders := make([][]string, len(holders))
+	errs := make([]error, len(holders))
+	var wg sync.WaitGroup
+	for i, holder := range holders {
+		wg.Add(1)
+		go func(i int, holder string) {
+			defer wg.Done()
+
+			lease := election.NewFileLease(path)
+			for n := 0; n < 200 && errs[i] == nil; n++ {
+				var leader string
+				if leader, errs[i] = lease.TryAcquire(ctx, holder); errs[i] != nil {
+					return
+				}
+				leaders[i] = append(leaders[i], leader)
+				if leader == holder {
+					errs[i] = lease.Release(ctx, holder)
+				}
+			}
+		}(i, holder)
+	}
+	wg.Wait()
+
+	// The holder of the other lease is read whole or not at all
+	for i := range holders {
+		require.NoError(t, errs[i])
+		for _, leader := range leaders[i] {
+			require.Contains(t, append([]string{""}, holders...), leader)
+		}
+	}
+}
+
+func TestElector_RejectsRequestsOnFollower(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "leader.lock")
+
+	leaderCtx, cancelLeader := context.WithCancel(ctx)
+	defer cancelLeader()
+	leader := election.New(election.NewFileLease(path), "tcp://leader", election.WithRenewInterval(renewInterval))
+	go leader.Run(leaderCtx)
+	require.Eventually(t, leader.IsLeader, time.Second, renewInterval)
+
+	follower := election.New(election.NewFileLease(path), "tcp://follower", election.WithRenewInterval(renewInterval))
+	go follower.Run(ctx)
+	require.Eventually(t, func() bool { return follower.Leader() == "tcp://leader" }, time.Second, renewInterval)
+
+	server := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithNSFrontServers(election.NewNetworkServiceRegistryServer(follower)),
+		registryserver.WithNSEFrontServers(election.NewNetworkServiceEndpointRegistryServer(follower)),
+	)
+	nsClient := adapters.NetworkServiceServerToClient(server.NetworkServiceRegistryServer())
+	nseClient := adapters.NetworkServiceEndpointServerToClient(server.NetworkServiceEndpointRegistryServer())
+
+	_, err := nsClient.Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Contains(t, err.Error(), "tcp://leader")
+
+	_, err = nseClient.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// The follower has no state to find in
+	_, err = nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = nsClient.Find(ctx, &registry.NetworkServiceQuery{NetworkService: new(registry.NetworkService)})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// The follower takes over the lease released by the leader
+	cancelLeader()
+	require.Eventually(t, follower.IsLeader, time.Second, renewInterval)
+
+	_, err = nsClient.Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+	stream, err := nsClient.Find(ctx, &registry.NetworkServiceQuery{NetworkService: new(registry.NetworkService)})
+	require.NoError(t, err)
+	require.Len(t, registry.ReadNetworkServiceList(stream), 1)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package election
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// FileLease is a lease backed by an exclusive lock of a file shared by the registries running on the same host. The
+// lock is released by the operating system when the holder crashes. The holder is written to the separate holder file
+// next to the lock file, it is replaced atomically, so the other registries read it without the lock.
+type FileLease struct {
+	path       string
+	holderPath string
+
+	mu     sync.Mutex
+	file   *os.File
+	holder string
+}
+
+// NewFileLease creates a lease backed by the lock of the file
+func NewFileLease(path string) *FileLease {
+	return &FileLease{
+		path:       path,
+		holderPath: path + ".holder",
+	}
+}
+
+// TryAcquire implements Lease
+func (l *FileLease) TryAcquire(_ context.Context, holder string) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file != nil {
+		return l.holder, nil
+	}
+
+	f, err := os.OpenFile(filepath.Clean(l.path), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to open %s", l.path)
+	}
+	if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		defer func() { _ = f.Close() }()
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			return "", errors.Wrapf(err, "failed to lock %s", l.path)
+		}
+		data, readErr := os.ReadFile(l.holderPath)
+		if readErr != nil && !os.IsNotExist(readErr) {
+			return "", errors.Wrapf(readErr, "failed to read %s", l.holderPath)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	if err = writeHolder(l.holderPath, holder); err != nil {
+		_ = f.Close()
+		return "", errors.Wrapf(err, "failed to write %s", l.holderPath)
+	}
+	l.file = f
+	l.holder = holder
+	return holder, nil
+}
+
+// Release implements Lease
+func (l *FileLease) Release(_ context.Context, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil || l.holder != holder {
+		return nil
+	}
+	// The holder is removed while the lock is still held, so it doesn't remove the holder of the next lease
+	err := os.Remove(l.holderPath)
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	l.holder = ""
+	return errors.Wrapf(err, "failed to release %s", l.path)
+}
+
+// writeHolder replaces the holder file by the temporary file with the holder, so the holder is never read partially
+func writeHolder(path, holder string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	_, err = f.WriteString(holder)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
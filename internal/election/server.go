@@ -0,0 +1,111 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package election
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// LeaderHeader is the header carrying url of the leader in the responses of the registries which are not the leader
+const LeaderHeader = "registry-leader"
+
+// notLeaderError returns the error redirecting the client to the leader
+func notLeaderError(ctx context.Context, e *Elector) error {
+	leader := e.Leader()
+	if leader == "" {
+		return status.Error(codes.Unavailable, "registry leader is not elected")
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(LeaderHeader, leader))
+	return status.Errorf(codes.FailedPrecondition, "registry is not the leader, redirect to %s", leader)
+}
+
+type electionNSServer struct {
+	elector *Elector
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer chain element rejecting requests of
+// network services if the registry is not the leader, the followers don't keep the state to find them in
+func NewNetworkServiceRegistryServer(e *Elector) registry.NetworkServiceRegistryServer {
+	return &electionNSServer{
+		elector: e,
+	}
+}
+
+func (s *electionNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if !s.elector.IsLeader() {
+		return nil, notLeaderError(ctx, s.elector)
+	}
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *electionNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if !s.elector.IsLeader() {
+		return notLeaderError(server.Context(), s.elector)
+	}
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *electionNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if !s.elector.IsLeader() {
+		return nil, notLeaderError(ctx, s.elector)
+	}
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+type electionNSEServer struct {
+	elector *Elector
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element
+// rejecting requests of network service endpoints if the registry is not the leader, the followers don't keep the
+// state to find them in
+func NewNetworkServiceEndpointRegistryServer(e *Elector) registry.NetworkServiceEndpointRegistryServer {
+	return &electionNSEServer{
+		elector: e,
+	}
+}
+
+func (s *electionNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if !s.elector.IsLeader() {
+		return nil, notLeaderError(ctx, s.elector)
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *electionNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if !s.elector.IsLeader() {
+		return notLeaderError(server.Context(), s.elector)
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *electionNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if !s.elector.IsLeader() {
+		return nil, notLeaderError(ctx, s.elector)
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expiry tells the expirations of the network service endpoints from their unregistrations. The expire chain
+// element unregisters the expired endpoints with the values of the context they were registered with, so the
+// registrations are marked before the expire chain element and the marker is found on their expirations only. The
+// begin chain element unregisters the endpoints without their expiration times, so the marker keeps the expiration
+// time the endpoint has been registered with.
+package expiry
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type expiredKey struct{}
+
+// WithExpirationTime returns a context marking the unregistrations made with it as the expirations of the network
+// service endpoints registered with the expirationTime
+func WithExpirationTime(ctx context.Context, expirationTime *timestamppb.Timestamp) context.Context {
+	value := new(atomic.Pointer[timestamppb.Timestamp])
+	value.Store(expirationTime)
+	return context.WithValue(ctx, expiredKey{}, value)
+}
+
+// IsExpired returns true if the unregistration is an expiration of the network service endpoint
+func IsExpired(ctx context.Context) bool {
+	_, ok := ctx.Value(expiredKey{}).(*atomic.Pointer[timestamppb.Timestamp])
+	return ok
+}
+
+// ExpirationTime returns the expiration time the network service endpoint has been registered with if the
+// unregistration is an expiration of it, nil otherwise
+func ExpirationTime(ctx context.Context) *timestamppb.Timestamp {
+	if value, ok := ctx.Value(expiredKey{}).(*atomic.Pointer[timestamppb.Timestamp]); ok {
+		return value.Load()
+	}
+	return nil
+}
+
+type expiryNSEServer struct{}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element marking
+// the unregistrations made by the expire chain element as expirations. It should be placed right before the expire
+// chain element.
+func NewNetworkServiceEndpointRegistryServer() registry.NetworkServiceEndpointRegistryServer {
+	return new(expiryNSEServer)
+}
+
+func (s *expiryNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	// The expiration time is selected by the expire chain element, so it is known from the response only
+	ctx = WithExpirationTime(ctx, nil)
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Value(expiredKey{}).(*atomic.Pointer[timestamppb.Timestamp]).Store(resp.GetExpirationTime())
+	return resp, nil
+}
+
+func (s *expiryNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *expiryNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expiry_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/expiry"
+)
+
+// trackingNSEServer records the expiration times of the expired endpoints
+type trackingNSEServer struct {
+	mu      sync.Mutex
+	expired map[string]bool
+	times   map[string]*timestamppb.Timestamp
+}
+
+func (s *trackingNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *trackingNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *trackingNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	s.mu.Lock()
+	s.expired[nse.GetName()] = expiry.IsExpired(ctx)
+	s.times[nse.GetName()] = expiry.ExpirationTime(ctx)
+	s.mu.Unlock()
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+func (s *trackingNSEServer) get(name string) (expired, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expired, ok = s.expired[name]
+	return expired, ok
+}
+
+func (s *trackingNSEServer) expirationTime(name string) *timestamppb.Timestamp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.times[name]
+}
+
+func TestExpiry(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tracking := &trackingNSEServer{
+		expired: make(map[string]bool),
+		times:   make(map[string]*timestamppb.Timestamp),
+	}
+	server := chain.NewNetworkServiceEndpointRegistryServer(
+		begin.NewNetworkServiceEndpointRegistryServer(),
+		expiry.NewNetworkServiceEndpointRegistryServer(),
+		expire.NewNetworkServiceEndpointRegistryServer(ctx, expire.WithDefaultExpiration(time.Hour)),
+		tracking,
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+
+	// The expire chain element unregisters the endpoints earlier by the timeout of their registration requests
+	requestCtx, requestCancel := context.WithTimeout(ctx, time.Second)
+	defer requestCancel()
+	nse1, err := server.Register(requestCtx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-1",
+		ExpirationTime: timestamppb.New(time.Now().Add(1500 * time.Millisecond)),
+	})
+	require.NoError(t, err)
+	nse, err := server.Register(requestCtx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-2",
+		ExpirationTime: timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		expired, ok := tracking.get("nse-1")
+		return ok && expired
+	}, 5*time.Second, 20*time.Millisecond)
+	// The begin chain element unregisters the endpoint without its expiration time
+	require.True(t, proto.Equal(nse1.GetExpirationTime(), tracking.expirationTime("nse-1")))
+
+	unregisterCtx, unregisterCancel := context.WithTimeout(ctx, time.Second)
+	defer unregisterCancel()
+	_, err = server.Unregister(unregisterCtx, nse)
+	require.NoError(t, err)
+	expired, ok := tracking.get("nse-2")
+	require.True(t, ok)
+	require.False(t, expired)
+	require.Nil(t, tracking.expirationTime("nse-2"))
+}
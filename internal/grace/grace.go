@@ -0,0 +1,220 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grace provides the registry chain element keeping expired network service endpoints for a grace period
+// and their tombstones, so the late refreshes resurrect the same entries.
+package grace
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/expiry"
+)
+
+// staleEntry is an expired endpoint waiting for the end of the grace period to be deleted
+type staleEntry struct {
+	nse   *registry.NetworkServiceEndpoint
+	timer clock.Timer
+}
+
+// tombstone keeps the initial registration time of the deleted expired endpoint
+type tombstone struct {
+	initialRegistrationTime *timestamppb.Timestamp
+	timer                   clock.Timer
+}
+
+type graceNSEServer struct {
+	gracePeriod  time.Duration
+	tombstoneTTL time.Duration
+
+	mu         sync.Mutex
+	stale      map[string]*staleEntry
+	tombstones map[string]*tombstone
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element that
+// marks the expired endpoints stale for the grace period instead of unregistering them. The stale endpoints are hidden
+// from the non-watch Finds, but the watching clients get no deletion until the grace period ends. The tombstones of
+// the deleted expired endpoints are kept for the tombstone TTL. The refresh of a stale endpoint or an endpoint having
+// a tombstone resurrects it keeping its initial registration time.
+//
+// It should be placed before the expiry chain element to tell the expirations of the endpoints from their
+// unregistrations.
+func NewNetworkServiceEndpointRegistryServer(options ...Option) registry.NetworkServiceEndpointRegistryServer {
+	s := &graceNSEServer{
+		stale:      make(map[string]*staleEntry),
+		tombstones: make(map[string]*tombstone),
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+func (s *graceNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	s.mu.Lock()
+	resurrected := s.resurrect(nse)
+	s.mu.Unlock()
+
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		if resurrected != nil {
+			// The stale endpoint failed to be resurrected, so it is deleted right away
+			s.mu.Lock()
+			s.deleteLocked(ctx, next.NetworkServiceEndpointRegistryServer(ctx), resurrected)
+			s.mu.Unlock()
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// resurrect cancels the deletion of the stale endpoint or drops the tombstone of the endpoint being registered and
+// returns the stale endpoint if there is one
+func (s *graceNSEServer) resurrect(nse *registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	if entry, ok := s.stale[nse.GetName()]; ok {
+		entry.timer.Stop()
+		delete(s.stale, nse.GetName())
+		nse.InitialRegistrationTime = entry.nse.GetInitialRegistrationTime()
+		return entry.nse
+	}
+	if t, ok := s.tombstones[nse.GetName()]; ok {
+		t.timer.Stop()
+		delete(s.tombstones, nse.GetName())
+		nse.InitialRegistrationTime = t.initialRegistrationTime
+	}
+	return nil
+}
+
+func (s *graceNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if query.GetWatch() {
+		return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, &hideStaleFindServer{
+		NetworkServiceEndpointRegistry_FindServer: server,
+		s: s,
+	})
+}
+
+func (s *graceNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	expired := expiry.IsExpired(ctx)
+
+	s.mu.Lock()
+	if entry, isStale := s.stale[nse.GetName()]; isStale {
+		if expired {
+			// The stale endpoint is already waiting for the deletion
+			s.mu.Unlock()
+			return new(emptypb.Empty), nil
+		}
+		entry.timer.Stop()
+		delete(s.stale, nse.GetName())
+	}
+	if t, ok := s.tombstones[nse.GetName()]; ok {
+		t.timer.Stop()
+		delete(s.tombstones, nse.GetName())
+	}
+
+	if !expired {
+		s.mu.Unlock()
+		return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	}
+	if s.gracePeriod == 0 {
+		defer s.mu.Unlock()
+		resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+		if err == nil {
+			s.addTombstoneLocked(ctx, nse)
+		}
+		return resp, err
+	}
+
+	// The deletion is delayed, so the context of the expiration should not cancel it
+	deleteCtx := context.WithoutCancel(ctx)
+	nextServer := next.NetworkServiceEndpointRegistryServer(ctx)
+	entry := &staleEntry{nse: nse.Clone()}
+	entry.timer = clock.FromContext(ctx).AfterFunc(s.gracePeriod, func() {
+		// The lock is held until the endpoint is deleted, so it can't race with its resurrection
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.stale[nse.GetName()] != entry {
+			return
+		}
+		delete(s.stale, nse.GetName())
+		s.deleteLocked(deleteCtx, nextServer, entry.nse)
+	})
+	s.stale[nse.GetName()] = entry
+	s.mu.Unlock()
+
+	log.FromContext(ctx).Infof("network service endpoint %s is stale for %v", nse.GetName(), s.gracePeriod)
+	return new(emptypb.Empty), nil
+}
+
+// deleteLocked unregisters the expired endpoint by the rest of the chain and keeps its tombstone
+func (s *graceNSEServer) deleteLocked(ctx context.Context, nextServer registry.NetworkServiceEndpointRegistryServer, nse *registry.NetworkServiceEndpoint) {
+	if _, err := nextServer.Unregister(ctx, nse); err != nil {
+		log.FromContext(ctx).Errorf("failed to unregister expired network service endpoint %s: %s", nse.GetName(), err.Error())
+		return
+	}
+	s.addTombstoneLocked(ctx, nse)
+}
+
+func (s *graceNSEServer) addTombstoneLocked(ctx context.Context, nse *registry.NetworkServiceEndpoint) {
+	if s.tombstoneTTL == 0 {
+		return
+	}
+	if t, ok := s.tombstones[nse.GetName()]; ok {
+		t.timer.Stop()
+	}
+	t := &tombstone{initialRegistrationTime: nse.GetInitialRegistrationTime()}
+	t.timer = clock.FromContext(ctx).AfterFunc(s.tombstoneTTL, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.tombstones[nse.GetName()] == t {
+			delete(s.tombstones, nse.GetName())
+		}
+	})
+	s.tombstones[nse.GetName()] = t
+}
+
+func (s *graceNSEServer) isStale(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.stale[name]
+	return ok
+}
+
+type hideStaleFindServer struct {
+	registry.NetworkServiceEndpointRegistry_FindServer
+	s *graceNSEServer
+}
+
+func (f *hideStaleFindServer) Send(resp *registry.NetworkServiceEndpointResponse) error {
+	if f.s.isStale(resp.GetNetworkServiceEndpoint().GetName()) {
+		return nil
+	}
+	return f.NetworkServiceEndpointRegistry_FindServer.Send(resp)
+}
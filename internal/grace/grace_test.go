@@ -0,0 +1,120 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grace_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+const (
+	// The expire chain element unregisters the endpoints earlier by the timeout of their registration requests
+	requestTimeout = 100 * time.Millisecond
+	expiration     = 300 * time.Millisecond
+	gracePeriod    = 500 * time.Millisecond
+	tombstoneTTL   = time.Second
+)
+
+func find(ctx context.Context, t *testing.T, client registry.NetworkServiceEndpointRegistryClient) []*registry.NetworkServiceEndpoint {
+	stream, err := client.Find(ctx, &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint)})
+	require.NoError(t, err)
+	return registry.ReadNetworkServiceEndpointList(stream)
+}
+
+func register(t *testing.T, client registry.NetworkServiceEndpointRegistryClient) *registry.NetworkServiceEndpoint {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := client.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(expiration)),
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestGrace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	registryServer := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithGracePeriod(gracePeriod),
+		registryserver.WithTombstoneTTL(tombstoneTTL),
+	)
+	client := adapters.NetworkServiceEndpointServerToClient(registryServer.NetworkServiceEndpointRegistryServer())
+
+	watch, err := client.Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+		Watch:                  true,
+	})
+	require.NoError(t, err)
+	events := make(chan *registry.NetworkServiceEndpointResponse, 100)
+	go func() {
+		for {
+			event, recvErr := watch.Recv()
+			if recvErr != nil {
+				return
+			}
+			events <- event
+		}
+	}()
+	deleted := func() bool {
+		for {
+			select {
+			case event := <-events:
+				if event.GetDeleted() {
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}
+
+	initialRegistrationTime := register(t, client).GetInitialRegistrationTime().AsTime()
+
+	// The expired endpoint is hidden, but not deleted until the end of the grace period
+	require.Eventually(t, func() bool { return len(find(ctx, t, client)) == 0 }, gracePeriod, 10*time.Millisecond)
+	require.False(t, deleted())
+
+	// The refresh of the stale endpoint resurrects it
+	require.Equal(t, initialRegistrationTime, register(t, client).GetInitialRegistrationTime().AsTime())
+	require.Len(t, find(ctx, t, client), 1)
+	require.False(t, deleted())
+
+	// The endpoint is deleted at the end of the grace period, but its refresh resurrects it by the tombstone
+	require.Eventually(t, deleted, expiration+2*gracePeriod, 10*time.Millisecond)
+	require.Empty(t, find(ctx, t, client))
+	require.Equal(t, initialRegistrationTime, register(t, client).GetInitialRegistrationTime().AsTime())
+
+	// The unregistered endpoint is deleted right away and has no tombstone
+	_, err = client.Unregister(context.Background(), &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	require.Eventually(t, deleted, time.Second, 10*time.Millisecond)
+	require.NotEqual(t, initialRegistrationTime, register(t, client).GetInitialRegistrationTime().AsTime())
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grace
+
+import "time"
+
+// Option is an option of the grace chain element
+type Option func(*graceNSEServer)
+
+// WithGracePeriod sets the period the expired endpoints are kept stale before the deletion
+func WithGracePeriod(d time.Duration) Option {
+	return func(s *graceNSEServer) {
+		s.gracePeriod = d
+	}
+}
+
+// WithTombstoneTTL sets the period the tombstones of the deleted expired endpoints are kept for
+func WithTombstoneTTL(d time.Duration) Option {
+	return func(s *graceNSEServer) {
+		s.tombstoneTTL = d
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+)
+
+// X509SVIDCheck checks the X509 SVID of the source is valid
+func X509SVIDCheck(source x509svid.Source) Check {
+	return func(ctx context.Context) error {
+		svid, err := source.GetX509SVID()
+		if err != nil {
+			return errors.Wrap(err, "failed to get X509 SVID")
+		}
+		if len(svid.Certificates) == 0 {
+			return errors.Errorf("X509 SVID %s has no certificates", svid.ID)
+		}
+		now := clock.FromContext(ctx).Now()
+		if cert := svid.Certificates[0]; now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			return errors.Errorf("X509 SVID %s is valid from %v to %v", svid.ID, cert.NotBefore, cert.NotAfter)
+		}
+		return nil
+	}
+}
+
+// ConnCheck checks the connection becomes ready, the connection is established if it is idle
+func ConnCheck(cc *grpc.ClientConn) Check {
+	return func(ctx context.Context) error {
+		cc.Connect()
+		for {
+			state := cc.GetState()
+			if state == connectivity.Ready {
+				return nil
+			}
+			if !cc.WaitForStateChange(ctx, state) {
+				return errors.Errorf("connection to %s is %s", cc.Target(), state)
+			}
+		}
+	}
+}
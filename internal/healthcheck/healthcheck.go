@@ -0,0 +1,241 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package healthcheck tracks the health of the registry components and reports the liveness and readiness of the
+// registry by the gRPC health service and the HTTP /livez and /readyz endpoints
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// livenessChecks is the number of the check intervals without completed checks after which the registry is not live
+const livenessChecks = 3
+
+var (
+	errNotChecked = errors.New("not checked yet")
+	errNotServing = errors.New("registry is not serving")
+)
+
+// Check returns an error if the component is not healthy
+type Check func(ctx context.Context) error
+
+type component struct {
+	name  string
+	check Check
+	err   error
+}
+
+// Health tracks the health of the registry components. The registry services are reported as serving by the gRPC
+// health service if the registry is serving and all its components are healthy, the overall "" service reports the
+// liveness.
+type Health struct {
+	server   *health.Server
+	services []string
+	started  time.Time
+
+	mu         sync.Mutex
+	components []*component
+	serving    bool
+	interval   time.Duration
+	lastCheck  time.Time
+}
+
+// New creates a new Health reporting the readiness of the services
+func New(ctx context.Context, services ...string) *Health {
+	h := &Health{
+		server:   health.NewServer(),
+		services: services,
+		started:  clock.FromContext(ctx).Now(),
+	}
+	h.updateLocked()
+	return h
+}
+
+// AddCheck adds the check of the component, the component is not healthy until it is checked
+func (h *Health) AddCheck(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.components = append(h.components, &component{name: name, check: check, err: errNotChecked})
+	h.updateLocked()
+}
+
+// SetServing sets if the registry is serving, e.g. it is not serving in the standby mode or on the shutdown
+func (h *Health) SetServing(serving bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.serving = serving
+	h.updateLocked()
+}
+
+// Register registers the gRPC health service
+func (h *Health) Register(server *grpc.Server) {
+	grpc_health_v1.RegisterHealthServer(server, h.server)
+}
+
+// Check checks all components and updates the reported readiness
+func (h *Health) Check(ctx context.Context) {
+	h.mu.Lock()
+	components := append([]*component(nil), h.components...)
+	h.mu.Unlock()
+
+	errs := make([]error, len(components))
+	for i, c := range components {
+		errs[i] = c.check(ctx)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, c := range components {
+		switch {
+		case errs[i] != nil && (c.err == nil || c.err == errNotChecked):
+			log.FromContext(ctx).Warnf("%s is not healthy: %s", c.name, errs[i].Error())
+		case errs[i] == nil && c.err != nil:
+			log.FromContext(ctx).Infof("%s is healthy", c.name)
+		}
+		c.err = errs[i]
+	}
+	h.lastCheck = clock.FromContext(ctx).Now()
+	h.updateLocked()
+}
+
+// Run checks the components with the interval until the context is done, each check is limited by the interval
+func (h *Health) Run(ctx context.Context, interval time.Duration) {
+	h.mu.Lock()
+	h.interval = interval
+	h.mu.Unlock()
+
+	go h.reportLiveness(ctx, interval)
+
+	ticker := clock.FromContext(ctx).Ticker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			checkCtx, cancel := clock.FromContext(ctx).WithTimeout(ctx, interval)
+			h.Check(checkCtx)
+			cancel()
+		}
+	}
+}
+
+// reportLiveness updates the liveness reported by the overall "" service with the interval until the context is
+// done. It doesn't wait for the checks, so the stuck checks are reported too.
+func (h *Health) reportLiveness(ctx context.Context, interval time.Duration) {
+	ticker := clock.FromContext(ctx).Ticker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			status := grpc_health_v1.HealthCheckResponse_SERVING
+			if err := h.Live(ctx); err != nil {
+				status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			}
+			h.server.SetServingStatus("", status)
+		}
+	}
+}
+
+// Live returns an error if the checks have not completed for several intervals, e.g. they are stuck
+func (h *Health) Live(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.interval == 0 {
+		return nil
+	}
+	last := h.lastCheck
+	if last.IsZero() {
+		last = h.started
+	}
+	if since := clock.FromContext(ctx).Since(last); since > livenessChecks*h.interval {
+		return errors.Errorf("components are not checked for %v", since.Round(time.Second))
+	}
+	return nil
+}
+
+// Ready returns true if the registry is serving and all components are healthy, the report lists the statuses of
+// the registry and its components
+func (h *Health) Ready() (ready bool, report string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ready = h.readyLocked()
+	b := new(strings.Builder)
+	writeStatus(b, "serving", h.servingErrLocked())
+	for _, c := range h.components {
+		writeStatus(b, c.name, c.err)
+	}
+	return ready, b.String()
+}
+
+func writeStatus(b *strings.Builder, name string, err error) {
+	if err != nil {
+		_, _ = fmt.Fprintf(b, "[-]%s failed: %s\n", name, err.Error())
+		return
+	}
+	_, _ = fmt.Fprintf(b, "[+]%s ok\n", name)
+}
+
+func (h *Health) servingErrLocked() error {
+	if !h.serving {
+		return errNotServing
+	}
+	return nil
+}
+
+func (h *Health) readyLocked() bool {
+	if !h.serving {
+		return false
+	}
+	for _, c := range h.components {
+		if c.err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Health) updateLocked() {
+	status := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if h.readyLocked() {
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	for _, service := range h.services {
+		h.server.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck_test
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/healthcheck"
+)
+
+const service = "registry.NetworkServiceEndpointRegistry"
+
+func serve(t *testing.T, register func(server *grpc.Server)) (*grpc.Server, *grpc.ClientConn) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	register(server)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	cc, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+	return server, cc
+}
+
+func status(ctx context.Context, t *testing.T, cc *grpc.ClientConn, service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	resp, err := grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.GetStatus()
+}
+
+func TestHealth(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	h := healthcheck.New(ctx, service)
+	_, cc := serve(t, h.Register)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status(ctx, t, cc, service))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status(ctx, t, cc, ""))
+
+	h.SetServing(true)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status(ctx, t, cc, service))
+
+	// The component is not ready until it is checked
+	var checkErr atomic.Pointer[error]
+	checkErr.Store(new(error))
+	h.AddCheck("component", func(context.Context) error { return *checkErr.Load() })
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status(ctx, t, cc, service))
+
+	h.Check(ctx)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status(ctx, t, cc, service))
+	ready, report := h.Ready()
+	require.True(t, ready)
+	require.Equal(t, "[+]serving ok\n[+]component ok\n", report)
+
+	failure := errors.New("component is broken")
+	checkErr.Store(&failure)
+	h.Check(ctx)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status(ctx, t, cc, service))
+	ready, report = h.Ready()
+	require.False(t, ready)
+	require.Equal(t, "[+]serving ok\n[-]component failed: component is broken\n", report)
+
+	// Not serving registry is not ready even if its components are healthy
+	checkErr.Store(new(error))
+	h.Check(ctx)
+	h.SetServing(false)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status(ctx, t, cc, service))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status(ctx, t, cc, ""))
+}
+
+func TestHealth_Liveness(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	h := healthcheck.New(ctx, service)
+	releaseCh := make(chan struct{})
+	h.AddCheck("component", func(context.Context) error {
+		<-releaseCh
+		return nil
+	})
+	_, cc := serve(t, h.Register)
+
+	// The overall service is not serving if the checks are stuck
+	go h.Run(ctx, 20*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return status(ctx, t, cc, "") == grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 10*time.Millisecond)
+
+	close(releaseCh)
+	require.Eventually(t, func() bool {
+		return status(ctx, t, cc, "") == grpc_health_v1.HealthCheckResponse_SERVING
+	}, time.Second, 10*time.Millisecond)
+}
+
+func get(t *testing.T, server *httptest.Server, path string) (code int, body string) {
+	resp, err := http.Get(server.URL + path)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(data)
+}
+
+func TestHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := healthcheck.New(ctx, service)
+	releaseCh := make(chan struct{})
+	var blocked atomic.Bool
+	h.AddCheck("component", func(context.Context) error {
+		if blocked.Load() {
+			<-releaseCh
+		}
+		return nil
+	})
+	server := httptest.NewServer(healthcheck.NewHandler(h))
+	defer server.Close()
+
+	code, body := get(t, server, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Contains(t, body, "[-]serving failed")
+	require.Contains(t, body, "[-]component failed: not checked yet")
+
+	h.SetServing(true)
+	h.Check(ctx)
+	code, body = get(t, server, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "[+]serving ok\n[+]component ok\n", body)
+
+	// The registry is not live if the checks are stuck
+	blocked.Store(true)
+	go h.Run(ctx, 20*time.Millisecond)
+	require.Eventually(t, func() bool {
+		code, _ = get(t, server, "/livez")
+		return code == http.StatusServiceUnavailable
+	}, time.Second, 10*time.Millisecond)
+
+	blocked.Store(false)
+	close(releaseCh)
+	require.Eventually(t, func() bool {
+		code, _ = get(t, server, "/livez")
+		return code == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestConnCheck(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server, cc := serve(t, func(*grpc.Server) {})
+	check := healthcheck.ConnCheck(cc)
+	require.NoError(t, check(ctx))
+
+	server.Stop()
+	require.Eventually(t, func() bool {
+		checkCtx, checkCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer checkCancel()
+		return check(checkCtx) != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+type svidSource struct {
+	svid *x509svid.SVID
+}
+
+func (s *svidSource) GetX509SVID() (*x509svid.SVID, error) {
+	return s.svid, nil
+}
+
+func TestX509SVIDCheck(t *testing.T) {
+	now := time.Now()
+	source := &svidSource{svid: &x509svid.SVID{
+		ID:           spiffeid.RequireFromString("spiffe://example.org/registry"),
+		Certificates: []*x509.Certificate{{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(time.Hour)}},
+	}}
+	check := healthcheck.X509SVIDCheck(source)
+	require.NoError(t, check(context.Background()))
+
+	source.svid.Certificates[0].NotAfter = now.Add(-time.Minute)
+	require.Error(t, check(context.Background()))
+}
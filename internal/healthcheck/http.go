@@ -0,0 +1,69 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const timeout = 10 * time.Second
+
+// NewHandler returns the handler serving /livez and /readyz, they respond with 200 if the registry is live or ready
+// and 503 otherwise
+func NewHandler(h *Health) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", func(w http.ResponseWriter, r *http.Request) {
+		if err := h.Live(r.Context()); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ready, report := h.Ready()
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if !ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write([]byte(report))
+	})
+	return mux
+}
+
+// ListenAndServe serves /livez and /readyz on the address until the context is done
+func ListenAndServe(ctx context.Context, listenOn string, h *Health) {
+	log.FromContext(ctx).Infof("Health HTTP endpoint is enabled. Listening on %s", listenOn)
+	server := &http.Server{
+		Addr:         listenOn,
+		Handler:      NewHandler(h),
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.FromContext(ctx).Errorf("Failed to start health HTTP endpoint: %s", err.Error())
+	}
+}
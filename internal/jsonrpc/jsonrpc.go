@@ -0,0 +1,103 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonrpc provides the gRPC services defined without the generated code. The requests and the responses of
+// their unary methods are JSON documents wrapped into wrapperspb.BytesValue.
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Handler handles the request of the unary method
+type Handler func(ctx context.Context, in *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error)
+
+// Method is the unary method of the service
+type Method struct {
+	// Name is the name of the method in the service
+	Name string
+	// Handler handles the requests of the method
+	Handler Handler
+}
+
+// service is the implementation of the registered service, the methods are bound to their handlers
+type service struct{}
+
+// Register registers the service of the methods on the server
+func Register(server grpc.ServiceRegistrar, serviceName string, methods ...Method) {
+	desc := &grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*interface{})(nil),
+		Streams:     []grpc.StreamDesc{},
+	}
+	for _, m := range methods {
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: m.Name,
+			Handler:    methodHandler(FullMethod(serviceName, m.Name), m.Handler),
+		})
+	}
+	server.RegisterService(desc, service{})
+}
+
+// FullMethod returns the full name of the method of the service the clients invoke it by
+func FullMethod(serviceName, methodName string) string {
+	return "/" + serviceName + "/" + methodName
+}
+
+// Invoke calls the method through the connection. The request is marshaled to JSON, the response is unmarshaled
+// from JSON to resp. The request is empty if req is nil, the response is ignored if resp is nil.
+func Invoke(ctx context.Context, cc grpc.ClientConnInterface, method string, req, resp interface{}) error {
+	in := new(wrapperspb.BytesValue)
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal request")
+		}
+		in.Value = data
+	}
+	out := new(wrapperspb.BytesValue)
+	if err := cc.Invoke(ctx, method, in, out); err != nil {
+		return err
+	}
+	if resp == nil {
+		return nil
+	}
+	return errors.Wrap(json.Unmarshal(out.GetValue(), resp), "failed to unmarshal response")
+}
+
+func methodHandler(fullMethod string, h Handler) grpc.MethodHandler {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		in := new(wrapperspb.BytesValue)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return h(ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: fullMethod,
+		}
+		return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return h(ctx, req.(*wrapperspb.BytesValue))
+		})
+	}
+}
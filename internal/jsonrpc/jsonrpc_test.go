@@ -0,0 +1,92 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/jsonrpc"
+)
+
+type greeting struct {
+	Name string `json:"name"`
+}
+
+func TestInvoke(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var methods []string
+	server := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			methods = append(methods, info.FullMethod)
+			return handler(ctx, req)
+		}))
+	jsonrpc.Register(server, "test.Greeter",
+		jsonrpc.Method{
+			Name: "Hello",
+			Handler: func(_ context.Context, in *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+				req := new(greeting)
+				if err := json.Unmarshal(in.GetValue(), req); err != nil {
+					return nil, status.Error(codes.InvalidArgument, err.Error())
+				}
+				data, err := json.Marshal(&greeting{Name: "hello " + req.Name})
+				if err != nil {
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+				return wrapperspb.Bytes(data), nil
+			},
+		},
+		jsonrpc.Method{
+			Name: "Fail",
+			Handler: func(context.Context, *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+				return nil, status.Error(codes.PermissionDenied, "denied")
+			},
+		},
+	)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(ln) }()
+	defer server.Stop()
+
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	resp := new(greeting)
+	require.NoError(t, jsonrpc.Invoke(ctx, cc, jsonrpc.FullMethod("test.Greeter", "Hello"), &greeting{Name: "registry"}, resp))
+	require.Equal(t, "hello registry", resp.Name)
+
+	// The errors of the handlers keep their status codes
+	err = jsonrpc.Invoke(ctx, cc, "/test.Greeter/Fail", nil, nil)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	require.Equal(t, []string{"/test.Greeter/Hello", "/test.Greeter/Fail"}, methods)
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides the Prometheus metrics of the registry
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const (
+	namespace = "registry"
+
+	// RegistryPolicy is the policy label of the denials by the registry authorization policies
+	RegistryPolicy = "registry"
+
+	nsResource  = "ns"
+	nseResource = "nse"
+
+	timeout = 10 * time.Second
+)
+
+// Registry is a registry the metrics are collected from
+type Registry interface {
+	// Dump returns all network services and network service endpoints stored by the registry
+	Dump(ctx context.Context) ([]*registry.NetworkService, []*registry.NetworkServiceEndpoint, error)
+}
+
+// Quotas are the quotas of the stored network service endpoints their usage is collected from
+type Quotas interface {
+	// Usage calls f with the scope, the subject, the number of the stored endpoints and the limit of each quota
+	Usage(f func(scope, subject string, used, limit int))
+}
+
+// Metrics are the Prometheus metrics of the registry
+type Metrics struct {
+	registry    *prometheus.Registry
+	requests    *prometheus.CounterVec
+	expirations prometheus.Counter
+	watches     *prometheus.GaugeVec
+	denials     *prometheus.CounterVec
+	throttled   *prometheus.CounterVec
+	exceeded    *prometheus.CounterVec
+}
+
+// New creates the metrics of the registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of the registry requests by resource, method and result code.",
+		}, []string{"resource", "method", "code"}),
+		expirations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "expirations_total",
+			Help:      "Number of the expired network service endpoints.",
+		}),
+		watches: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "watch_streams",
+			Help:      "Number of the active watching Find streams by resource.",
+		}, []string{"resource"}),
+		denials: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "authorization_denials_total",
+			Help:      "Number of the requests denied by the authorization policies.",
+		}, []string{"policy"}),
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "throttled_requests_total",
+			Help:      "Number of the requests rejected by the rate limits by resource and method.",
+		}, []string{"resource", "method"}),
+		exceeded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "quota_exceeded_total",
+			Help:      "Number of the registrations rejected by the quotas by quota scope.",
+		}, []string{"scope"}),
+	}
+	m.registry.MustRegister(m.requests, m.expirations, m.watches, m.denials, m.throttled, m.exceeded)
+	return m
+}
+
+// CollectRegistry adds the numbers of the entries stored by the registry to the metrics. It should be called once
+// the registry is created, as the registry chain includes the chain elements of the metrics.
+func (m *Metrics) CollectRegistry(r Registry) {
+	m.registry.MustRegister(newRegistryCollector(r))
+}
+
+// CollectQuotas adds the usage and the limits of the quotas to the metrics
+func (m *Metrics) CollectQuotas(q Quotas) {
+	m.registry.MustRegister(newQuotaCollector(q))
+}
+
+// Handler returns the handler serving the metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Denied records the denial of a request by the authorization policy
+func (m *Metrics) Denied(policy string) {
+	m.denials.WithLabelValues(policy).Inc()
+}
+
+// Throttled records the rejection of a request by the rate limit
+func (m *Metrics) Throttled(resource, method string) {
+	m.throttled.WithLabelValues(resource, method).Inc()
+}
+
+// QuotaExceeded records the rejection of a registration by the quota of the scope
+func (m *Metrics) QuotaExceeded(scope string) {
+	m.exceeded.WithLabelValues(scope).Inc()
+}
+
+func (m *Metrics) observe(resource, method string, err error) {
+	m.requests.WithLabelValues(resource, method, status.Code(err).String()).Inc()
+	if status.Code(err) == codes.PermissionDenied {
+		m.Denied(RegistryPolicy)
+	}
+}
+
+// ListenAndServe serves the metrics on /metrics of the address until the context is done
+func ListenAndServe(ctx context.Context, listenOn string, m *Metrics) {
+	log.FromContext(ctx).Infof("Prometheus metrics are enabled. Listening on %s", listenOn)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	server := &http.Server{
+		Addr:         listenOn,
+		Handler:      mux,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.FromContext(ctx).Errorf("Failed to start Prometheus metrics listener: %s", err.Error())
+	}
+}
+
+// registryCollector collects the numbers of the stored entries on scrape
+type registryCollector struct {
+	registry                Registry
+	networkServices         *prometheus.Desc
+	networkServiceEndpoints *prometheus.Desc
+	endpointsByService      *prometheus.Desc
+}
+
+func newRegistryCollector(r Registry) *registryCollector {
+	return &registryCollector{
+		registry: r,
+		networkServices: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "network_services"),
+			"Number of the stored network services.", nil, nil),
+		networkServiceEndpoints: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "network_service_endpoints"),
+			"Number of the stored network service endpoints.", nil, nil),
+		endpointsByService: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "network_service_endpoints_by_service"),
+			"Number of the stored network service endpoints by network service.", []string{"network_service"}, nil),
+	}
+}
+
+func (c *registryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.networkServices
+	ch <- c.networkServiceEndpoints
+	ch <- c.endpointsByService
+}
+
+func (c *registryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	nss, nses, err := c.registry.Dump(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.networkServices, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.networkServices, prometheus.GaugeValue, float64(len(nss)))
+	ch <- prometheus.MustNewConstMetric(c.networkServiceEndpoints, prometheus.GaugeValue, float64(len(nses)))
+
+	endpoints := make(map[string]int)
+	for _, nse := range nses {
+		for _, ns := range nse.GetNetworkServiceNames() {
+			endpoints[ns]++
+		}
+	}
+	for ns, count := range endpoints {
+		ch <- prometheus.MustNewConstMetric(c.endpointsByService, prometheus.GaugeValue, float64(count), ns)
+	}
+}
+
+// quotaCollector collects the usage of the quotas on scrape
+type quotaCollector struct {
+	quotas Quotas
+	used   *prometheus.Desc
+	limit  *prometheus.Desc
+}
+
+func newQuotaCollector(q Quotas) *quotaCollector {
+	return &quotaCollector{
+		quotas: q,
+		used: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "quota_used"),
+			"Number of the stored network service endpoints charged to the quota by scope and subject.", []string{"scope", "subject"}, nil),
+		limit: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "quota_limit"),
+			"Limit of the quota by scope and subject, unlimited quotas are omitted.", []string{"scope", "subject"}, nil),
+	}
+}
+
+func (c *quotaCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.used
+	ch <- c.limit
+}
+
+func (c *quotaCollector) Collect(ch chan<- prometheus.Metric) {
+	var metrics []prometheus.Metric
+	c.quotas.Usage(func(scope, subject string, used, limit int) {
+		metrics = append(metrics, prometheus.MustNewConstMetric(c.used, prometheus.GaugeValue, float64(used), scope, subject))
+		if limit > 0 {
+			metrics = append(metrics, prometheus.MustNewConstMetric(c.limit, prometheus.GaugeValue, float64(limit), scope, subject))
+		}
+	})
+	for _, metric := range metrics {
+		ch <- metric
+	}
+}
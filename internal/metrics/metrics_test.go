@@ -0,0 +1,163 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/metrics"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/quota"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+const denyPolicy = `package nsm
+
+default valid = false
+`
+
+func scrape(t *testing.T, server *httptest.Server) string {
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The network services are denied to count the denials
+	policyPath := filepath.Join(t.TempDir(), "deny.rego")
+	require.NoError(t, os.WriteFile(policyPath, []byte(denyPolicy), 0o600))
+
+	m := metrics.New()
+	quotas := quota.New(quota.WithLimits(quota.Limits{Total: 10}), quota.WithOnExceeded(m.QuotaExceeded))
+	registryServer := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithAuthorizeNSRegistryServer(authorize.NewNetworkServiceRegistryServer(authorize.WithPolicies(policyPath))),
+		registryserver.WithNSFrontServers(metrics.NewNetworkServiceRegistryServer(m)),
+		registryserver.WithNSEFrontServers(metrics.NewNetworkServiceEndpointRegistryServer(m)),
+		registryserver.WithNSERegistryServers(metrics.NewExpirationNetworkServiceEndpointRegistryServer(m)),
+		registryserver.WithNSERegistryServers(quota.NewNetworkServiceEndpointRegistryServer(quotas)),
+	)
+	m.CollectRegistry(registryServer)
+	m.CollectQuotas(quotas)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	registryServer.Register(server)
+	go func() { _ = server.Serve(ln) }()
+	defer server.Stop()
+
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	nsClient := next.NewNetworkServiceRegistryClient(
+		grpcmetadata.NewNetworkServiceRegistryClient(),
+		registry.NewNetworkServiceRegistryClient(cc),
+	)
+	nseClient := next.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(cc),
+	)
+
+	_, err = nsClient.Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.Error(t, err)
+
+	_, err = nseClient.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	// The expire chain element unregisters the endpoint earlier by the timeout of its registration request
+	requestCtx, requestCancel := context.WithTimeout(ctx, time.Second)
+	defer requestCancel()
+	_, err = nseClient.Register(requestCtx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-2",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(1500 * time.Millisecond)),
+	})
+	require.NoError(t, err)
+
+	watchCtx, cancelWatch := context.WithCancel(ctx)
+	defer cancelWatch()
+	_, err = nseClient.Find(watchCtx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+		Watch:                  true,
+	})
+	require.NoError(t, err)
+
+	m.Throttled("nse", "Register")
+	m.QuotaExceeded(quota.ScopeTotal)
+
+	metricsServer := httptest.NewServer(m.Handler())
+	defer metricsServer.Close()
+
+	require.Eventually(t, func() bool {
+		body := scrape(t, metricsServer)
+		return contains(body,
+			`registry_requests_total{code="PermissionDenied",method="Register",resource="ns"} 1`,
+			`registry_requests_total{code="OK",method="Register",resource="nse"} 2`,
+			`registry_authorization_denials_total{policy="registry"} 1`,
+			`registry_throttled_requests_total{method="Register",resource="nse"} 1`,
+			`registry_quota_exceeded_total{scope="total"} 1`,
+			`registry_quota_used{scope="total",subject=""} 1`,
+			`registry_quota_limit{scope="total",subject=""} 10`,
+			`registry_quota_used{scope="network_service",subject="ns-1"} 1`,
+			`registry_watch_streams{resource="nse"} 1`,
+			`registry_expirations_total 1`,
+			`registry_network_services 0`,
+			`registry_network_service_endpoints 1`,
+			`registry_network_service_endpoints_by_service{network_service="ns-1"} 1`,
+		)
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func contains(body string, lines ...string) bool {
+	for _, line := range lines {
+		if !strings.Contains(body, line) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type metricsNSServer struct {
+	metrics *Metrics
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer chain element that counts the
+// network service requests. It should be placed before the authorization to count the denials.
+func NewNetworkServiceRegistryServer(m *Metrics) registry.NetworkServiceRegistryServer {
+	return &metricsNSServer{
+		metrics: m,
+	}
+}
+
+func (s *metricsNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	resp, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	s.metrics.observe(nsResource, "Register", err)
+	return resp, err
+}
+
+func (s *metricsNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if query.GetWatch() {
+		s.metrics.watches.WithLabelValues(nsResource).Inc()
+		defer s.metrics.watches.WithLabelValues(nsResource).Dec()
+	}
+	err := next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+	s.metrics.observe(nsResource, "Find", err)
+	return err
+}
+
+func (s *metricsNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+	s.metrics.observe(nsResource, "Unregister", err)
+	return resp, err
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/expiry"
+)
+
+type metricsNSEServer struct {
+	metrics *Metrics
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element that
+// counts the network service endpoint requests. It should be placed before the authorization to count the denials.
+func NewNetworkServiceEndpointRegistryServer(m *Metrics) registry.NetworkServiceEndpointRegistryServer {
+	return &metricsNSEServer{
+		metrics: m,
+	}
+}
+
+func (s *metricsNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	s.metrics.observe(nseResource, "Register", err)
+	return resp, err
+}
+
+func (s *metricsNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if query.GetWatch() {
+		s.metrics.watches.WithLabelValues(nseResource).Inc()
+		defer s.metrics.watches.WithLabelValues(nseResource).Dec()
+	}
+	err := next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+	s.metrics.observe(nseResource, "Find", err)
+	return err
+}
+
+func (s *metricsNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	s.metrics.observe(nseResource, "Unregister", err)
+	return resp, err
+}
+
+type expirationNSEServer struct {
+	metrics *Metrics
+}
+
+// NewExpirationNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element
+// that counts the expired network service endpoints. It should be placed after the expire chain element to observe
+// expirations.
+func NewExpirationNetworkServiceEndpointRegistryServer(m *Metrics) registry.NetworkServiceEndpointRegistryServer {
+	return &expirationNSEServer{
+		metrics: m,
+	}
+}
+
+func (s *expirationNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *expirationNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *expirationNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	resp, err := next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+	if expiry.IsExpired(ctx) {
+		s.metrics.expirations.Inc()
+	}
+	return resp, nil
+}
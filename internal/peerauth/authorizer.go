@@ -0,0 +1,90 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peerauth
+
+import (
+	"crypto/x509"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+)
+
+const (
+	trustDomainRule = "trust-domain:"
+	prefixRule      = "prefix:"
+	regexRule       = "regex:"
+)
+
+// NewAuthorizer creates the authorizer of the peers presenting the certificate with the SPIFFE ID allowed by any of
+// the rules. The rules are trust-domain:<trust domain>, prefix:<SPIFFE ID prefix> or regex:<regex matching whole SPIFFE ID>, any
+// peer is authorized if there are no rules.
+func NewAuthorizer(rules []string) (tlsconfig.Authorizer, error) {
+	if len(rules) == 0 {
+		return tlsconfig.AuthorizeAny(), nil
+	}
+	var matchers []func(id spiffeid.ID) bool
+	for _, rule := range rules {
+		matcher, err := parseRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+	return func(id spiffeid.ID, _ [][]*x509.Certificate) error {
+		for _, matches := range matchers {
+			if matches(id) {
+				return nil
+			}
+		}
+		return errors.Errorf("peer %s is not allowed", id)
+	}, nil
+}
+
+func parseRule(rule string) (func(id spiffeid.ID) bool, error) {
+	switch {
+	case strings.HasPrefix(rule, trustDomainRule):
+		td, err := spiffeid.TrustDomainFromString(strings.TrimPrefix(rule, trustDomainRule))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid peer rule %q", rule)
+		}
+		return func(id spiffeid.ID) bool {
+			return id.MemberOf(td)
+		}, nil
+	case strings.HasPrefix(rule, prefixRule):
+		prefix := strings.TrimPrefix(rule, prefixRule)
+		if _, err := spiffeid.FromString(strings.TrimSuffix(prefix, "/")); err != nil {
+			return nil, errors.Wrapf(err, "invalid peer rule %q", rule)
+		}
+		return func(id spiffeid.ID) bool {
+			return strings.HasPrefix(id.String(), prefix)
+		}, nil
+	case strings.HasPrefix(rule, regexRule):
+		// The regex should match the whole SPIFFE ID, so the unanchored one does not allow unexpected peers
+		re, err := regexp.Compile("^(?:" + strings.TrimPrefix(rule, regexRule) + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid peer rule %q", rule)
+		}
+		return func(id spiffeid.ID) bool {
+			return re.MatchString(id.String())
+		}, nil
+	default:
+		return nil, errors.Errorf("invalid peer rule %q, expected trust-domain:, prefix: or regex: form", rule)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package peerauth provides authorization of the peers of the registry connections
+package peerauth
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// AuthorizeID authorizes the peers presenting the certificate with the SPIFFE ID
+func AuthorizeID(id spiffeid.ID) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		peerID, err := PeerID(ctx)
+		if err != nil {
+			return err
+		}
+		if peerID != id {
+			return errors.Errorf("peer %s is not authorized", peerID)
+		}
+		return nil
+	}
+}
+
+// PeerID returns the SPIFFE ID of the certificate presented by the peer
+func PeerID(ctx context.Context) (spiffeid.ID, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return spiffeid.ID{}, errors.New("no peer in the context")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return spiffeid.ID{}, errors.New("peer has no certificate")
+	}
+	peerID, err := x509svid.IDFromCert(tlsInfo.State.PeerCertificates[0])
+	if err != nil {
+		return spiffeid.ID{}, errors.Wrap(err, "failed to get peer SPIFFE ID")
+	}
+	return peerID, nil
+}
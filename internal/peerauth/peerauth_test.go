@@ -0,0 +1,134 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peerauth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/peerauth"
+)
+
+func TestNewAuthorizer(t *testing.T) {
+	authorizer, err := peerauth.NewAuthorizer([]string{
+		"trust-domain:example.org",
+		"prefix:spiffe://partner.org/ns/nsm-system/",
+		"regex:spiffe://other\\.org/nsmgr-[0-9]+",
+	})
+	require.NoError(t, err)
+
+	for id, allowed := range map[string]bool{
+		"spiffe://example.org/nse":                    true,
+		"spiffe://partner.org/ns/nsm-system/registry": true,
+		"spiffe://partner.org/ns/default/nse":         false,
+		"spiffe://other.org/nsmgr-1":                  true,
+		"spiffe://other.org/nsmgr-1/forwarder":        false,
+		"spiffe://evil.org/nse":                       false,
+	} {
+		err = authorizer(spiffeid.RequireFromString(id), nil)
+		require.Equal(t, allowed, err == nil, id)
+	}
+
+	// Any peer is authorized without the rules
+	authorizer, err = peerauth.NewAuthorizer(nil)
+	require.NoError(t, err)
+	require.NoError(t, authorizer(spiffeid.RequireFromString("spiffe://evil.org/nse"), nil))
+
+	for _, rule := range []string{"example.org", "trust-domain:Example Org", "prefix:example.org/", "regex:("} {
+		_, err = peerauth.NewAuthorizer([]string{rule})
+		require.Error(t, err, rule)
+	}
+}
+
+type svidSource struct {
+	svid *x509svid.SVID
+}
+
+func (s *svidSource) GetX509SVID() (*x509svid.SVID, error) {
+	return s.svid, nil
+}
+
+func newSVID(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, id string) *x509svid.SVID {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	u, err := url.Parse(id)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{u},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &x509svid.SVID{ID: spiffeid.RequireFromString(id), Certificates: []*x509.Certificate{cert}, PrivateKey: key}
+}
+
+func TestNewAuthorizer_Handshake(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		URIs:                  []*url.URL{td.ID().URL()},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, &x509.Certificate{SerialNumber: big.NewInt(1), URIs: []*url.URL{td.ID().URL()}}, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	bundle := x509bundle.FromX509Authorities(td, []*x509.Certificate{ca})
+
+	serverSource := &svidSource{svid: newSVID(t, ca, caKey, "spiffe://example.org/registry")}
+	authorizer, err := peerauth.NewAuthorizer([]string{"prefix:spiffe://example.org/nsmgr"})
+	require.NoError(t, err)
+
+	// handshake returns the error of the server handshake with the client presenting the SVID of the SPIFFE ID
+	handshake := func(id string) error {
+		clientConn, serverConn := net.Pipe()
+		defer func() { _ = serverConn.Close() }()
+		client := tls.Client(clientConn, tlsconfig.MTLSClientConfig(&svidSource{svid: newSVID(t, ca, caKey, id)}, bundle, tlsconfig.AuthorizeAny()))
+		go func() {
+			_ = client.Handshake()
+			_, _ = client.Read(make([]byte, 1))
+			_ = clientConn.Close()
+		}()
+		return tls.Server(serverConn, tlsconfig.MTLSServerConfig(serverSource, bundle, authorizer)).Handshake()
+	}
+
+	require.ErrorContains(t, handshake("spiffe://example.org/nse"), "peer spiffe://example.org/nse is not allowed")
+	require.NoError(t, handshake("spiffe://example.org/nsmgr-1"))
+}
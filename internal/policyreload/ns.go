@@ -0,0 +1,74 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyreload
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type reloadNSServer struct {
+	policies *Policies
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer chain element
+// authorizing the network service requests with the registry server policies
+func NewNetworkServiceRegistryServer(p *Policies) registry.NetworkServiceRegistryServer {
+	return &reloadNSServer{
+		policies: p,
+	}
+}
+
+func (s *reloadNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	return s.policies.current.Load().nsServer.Register(ctx, ns)
+}
+
+func (s *reloadNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return s.policies.current.Load().nsServer.Find(query, server)
+}
+
+func (s *reloadNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return s.policies.current.Load().nsServer.Unregister(ctx, ns)
+}
+
+type reloadNSClient struct {
+	policies *Policies
+}
+
+// NewNetworkServiceRegistryClient creates a new NetworkServiceRegistryClient chain element
+// authorizing the network service requests with the registry client policies
+func NewNetworkServiceRegistryClient(p *Policies) registry.NetworkServiceRegistryClient {
+	return &reloadNSClient{
+		policies: p,
+	}
+}
+
+func (c *reloadNSClient) Register(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*registry.NetworkService, error) {
+	return c.policies.current.Load().nsClient.Register(ctx, ns, opts...)
+}
+
+func (c *reloadNSClient) Find(ctx context.Context, query *registry.NetworkServiceQuery, opts ...grpc.CallOption) (registry.NetworkServiceRegistry_FindClient, error) {
+	return c.policies.current.Load().nsClient.Find(ctx, query, opts...)
+}
+
+func (c *reloadNSClient) Unregister(ctx context.Context, ns *registry.NetworkService, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.policies.current.Load().nsClient.Unregister(ctx, ns, opts...)
+}
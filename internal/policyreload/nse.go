@@ -0,0 +1,74 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyreload
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type reloadNSEServer struct {
+	policies *Policies
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element
+// authorizing the network service endpoint requests with the registry server policies
+func NewNetworkServiceEndpointRegistryServer(p *Policies) registry.NetworkServiceEndpointRegistryServer {
+	return &reloadNSEServer{
+		policies: p,
+	}
+}
+
+func (s *reloadNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	return s.policies.current.Load().nseServer.Register(ctx, nse)
+}
+
+func (s *reloadNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return s.policies.current.Load().nseServer.Find(query, server)
+}
+
+func (s *reloadNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return s.policies.current.Load().nseServer.Unregister(ctx, nse)
+}
+
+type reloadNSEClient struct {
+	policies *Policies
+}
+
+// NewNetworkServiceEndpointRegistryClient creates a new NetworkServiceEndpointRegistryClient chain element
+// authorizing the network service endpoint requests with the registry client policies
+func NewNetworkServiceEndpointRegistryClient(p *Policies) registry.NetworkServiceEndpointRegistryClient {
+	return &reloadNSEClient{
+		policies: p,
+	}
+}
+
+func (c *reloadNSEClient) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*registry.NetworkServiceEndpoint, error) {
+	return c.policies.current.Load().nseClient.Register(ctx, nse, opts...)
+}
+
+func (c *reloadNSEClient) Find(ctx context.Context, query *registry.NetworkServiceEndpointQuery, opts ...grpc.CallOption) (registry.NetworkServiceEndpointRegistry_FindClient, error) {
+	return c.policies.current.Load().nseClient.Find(ctx, query, opts...)
+}
+
+func (c *reloadNSEClient) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return c.policies.current.Load().nseClient.Unregister(ctx, nse, opts...)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policyreload provides the registry authorization chain elements whose OPA policies are reloaded from the
+// files without restarting the registry.
+package policyreload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/opa"
+)
+
+// authorizers are the authorization chain elements built from the same version of the policies
+type authorizers struct {
+	nsServer  registry.NetworkServiceRegistryServer
+	nseServer registry.NetworkServiceEndpointRegistryServer
+	nsClient  registry.NetworkServiceRegistryClient
+	nseClient registry.NetworkServiceEndpointRegistryClient
+}
+
+// Policies are the registry server and client policies. The chain elements created for the policies authorize the
+// requests with the latest successfully compiled version of them.
+type Policies struct {
+	serverPaths   []string
+	clientPaths   []string
+	nsPathIDsMap  *genericsync.Map[string, []string]
+	nsePathIDsMap *genericsync.Map[string, []string]
+	// mu serializes the reloads, the authorizers are swapped atomically for the requests in flight
+	mu      sync.Mutex
+	err     error
+	current atomic.Pointer[authorizers]
+}
+
+// New loads the registry server and client policies from the paths to files and directories
+func New(serverPaths, clientPaths []string) (*Policies, error) {
+	p := &Policies{
+		serverPaths:   serverPaths,
+		clientPaths:   clientPaths,
+		nsPathIDsMap:  new(genericsync.Map[string, []string]),
+		nsePathIDsMap: new(genericsync.Map[string, []string]),
+	}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Reload recompiles the policies and swaps them atomically. The current policies are kept if the new ones fail to
+// compile.
+func (p *Policies) Reload() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err = compile(p.serverPaths); p.err != nil {
+		p.err = errors.Wrap(p.err, "failed to compile registry server policies")
+		return p.err
+	}
+	if p.err = compile(p.clientPaths); p.err != nil {
+		p.err = errors.Wrap(p.err, "failed to compile registry client policies")
+		return p.err
+	}
+
+	// The paths of the registered entries are shared by the versions of the policies, so the entries registered
+	// before the reload stay owned by their clients
+	serverOptions := []authorize.Option{authorize.WithPolicies(p.serverPaths...)}
+	clientOptions := []authorize.Option{authorize.WithPolicies(p.clientPaths...)}
+	p.current.Store(&authorizers{
+		nsServer: authorize.NewNetworkServiceRegistryServer(
+			append(serverOptions, authorize.WithResourcePathIDsMap(p.nsPathIDsMap))...),
+		nseServer: authorize.NewNetworkServiceEndpointRegistryServer(
+			append(serverOptions, authorize.WithResourcePathIDsMap(p.nsePathIDsMap))...),
+		nsClient:  authorize.NewNetworkServiceRegistryClient(clientOptions...),
+		nseClient: authorize.NewNetworkServiceEndpointRegistryClient(clientOptions...),
+	})
+	return nil
+}
+
+// Err returns the error of the last reload, the current policies are stale if it is not nil
+func (p *Policies) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.err
+}
+
+// Run watches the directories of the policies and reloads them on changes until the context is done
+func (p *Policies) Run(ctx context.Context) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to watch policies: %s", err.Error())
+		return
+	}
+	defer func() { _ = watcher.Close() }()
+
+	for _, dir := range dirs(append(append([]string{}, p.serverPaths...), p.clientPaths...)) {
+		if err := watcher.Add(dir); err != nil {
+			log.FromContext(ctx).Errorf("failed to watch policies %s: %s", dir, err.Error())
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-watcher.Errors:
+			log.FromContext(ctx).Warnf("policies watch error: %s", err.Error())
+		case <-watcher.Events:
+			if err := p.Reload(); err != nil {
+				log.FromContext(ctx).Errorf("failed to reload policies, keeping the current ones: %s", err.Error())
+				continue
+			}
+			log.FromContext(ctx).Info("policies are reloaded")
+		}
+	}
+}
+
+// compile compiles the policies of the paths. The policies are compiled lazily on the first check, so they are
+// checked with an empty input: compilation errors are the only ones that are not gRPC statuses.
+func compile(paths []string) error {
+	policies, err := opa.PoliciesByFileMask(paths...)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		if err := policy.Check(context.Background(), struct{}{}); err != nil {
+			if _, ok := status.FromError(err); !ok {
+				return errors.Wrapf(err, "failed to compile policy %s", policy.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// dirs returns the existing directories of the paths, the paths may be directories, files or file masks. The
+// policies embedded into the registry have no directories to watch.
+func dirs(paths []string) []string {
+	var result []string
+	set := make(map[string]struct{})
+	for _, path := range paths {
+		dir := path
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			dir = filepath.Dir(path)
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		if _, ok := set[dir]; !ok {
+			set[dir] = struct{}{}
+			result = append(result, dir)
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyreload_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/policyreload"
+)
+
+const (
+	allowPolicy = `package nsm
+
+default valid = true
+`
+	denyPolicy = `package nsm
+
+default valid = false
+`
+	brokenPolicy = `package nsm
+
+valid {
+`
+)
+
+func TestPolicies_Reload(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "valid.rego")
+	require.NoError(t, os.WriteFile(policyPath, []byte(allowPolicy), 0o600))
+
+	policies, err := policyreload.New([]string{filepath.Join(dir, ".*.rego")}, nil)
+	require.NoError(t, err)
+	go policies.Run(ctx)
+
+	server := next.NewNetworkServiceRegistryServer(policyreload.NewNetworkServiceRegistryServer(policies))
+	_, err = server.Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+
+	// The changed policy is reloaded by the watcher
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, os.WriteFile(policyPath, []byte(denyPolicy), 0o600))
+	require.Eventually(t, func() bool {
+		_, err = server.Register(ctx, &registry.NetworkService{Name: "ns-1"})
+		return status.Code(err) == codes.PermissionDenied
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The current policy is kept if the new one fails to compile
+	cancel()
+	require.NoError(t, os.WriteFile(policyPath, []byte(brokenPolicy), 0o600))
+	require.Error(t, policies.Reload())
+	require.Error(t, policies.Err())
+	_, err = server.Register(context.Background(), &registry.NetworkService{Name: "ns-1"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	require.NoError(t, os.WriteFile(policyPath, []byte(denyPolicy), 0o600))
+	require.NoError(t, policies.Reload())
+	require.NoError(t, policies.Err())
+}
+
+func TestNew_BrokenPolicy(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "valid.rego")
+	require.NoError(t, os.WriteFile(policyPath, []byte(brokenPolicy), 0o600))
+
+	_, err := policyreload.New(nil, []string{policyPath})
+	require.Error(t, err)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+// Option modifies the quotas option value
+type Option func(q *Quotas)
+
+// WithLimits sets the limits of the stored network service endpoints
+func WithLimits(limits Limits) Option {
+	return func(q *Quotas) {
+		q.limits = limits
+	}
+}
+
+// WithTrustDomainLimit overrides the limit of the endpoints registered by each SPIFFE ID of the trust domain
+func WithTrustDomainLimit(trustDomain string, perID int) Option {
+	return func(q *Quotas) {
+		q.trustDomainLimits[trustDomain] = perID
+	}
+}
+
+// WithOnExceeded sets the function called with the scope of each exceeded quota
+func WithOnExceeded(onExceeded func(scope string)) Option {
+	return func(q *Quotas) {
+		q.onExceeded = onExceeded
+	}
+}
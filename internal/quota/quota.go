@@ -0,0 +1,305 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quota provides the registry chain element limiting the number of the stored network service endpoints for
+// each SPIFFE ID, for each network service and in total
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+const (
+	// ScopeID is the scope of the quotas of the endpoints registered by each SPIFFE ID
+	ScopeID = "spiffe_id"
+	// ScopeService is the scope of the quotas of the endpoints of each network service
+	ScopeService = "network_service"
+	// ScopeTotal is the scope of the quota of all endpoints
+	ScopeTotal = "total"
+)
+
+// Limits are the maximum numbers of the stored network service endpoints, the number is not limited if it is 0
+type Limits struct {
+	// PerID is the maximum number of the endpoints registered by each SPIFFE ID
+	PerID int
+	// PerService is the maximum number of the endpoints of each network service
+	PerService int
+	// Total is the maximum number of all endpoints
+	Total int
+}
+
+// ParseTrustDomainLimits parses the limits of the endpoints registered by each SPIFFE ID of the trust domains given
+// as <trust domain>=<limit>
+func ParseTrustDomainLimits(items []string) (map[string]int, error) {
+	result := make(map[string]int, len(items))
+	for _, item := range items {
+		i := strings.LastIndex(item, "=")
+		if i <= 0 {
+			return nil, errors.Errorf("invalid trust domain limit %q, expected <trust domain>=<limit>", item)
+		}
+		td, err := spiffeid.TrustDomainFromString(item[:i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trust domain limit %q", item)
+		}
+		limit, err := strconv.Atoi(item[i+1:])
+		if err != nil || limit < 0 {
+			return nil, errors.Errorf("invalid trust domain limit %q, limit should be a non-negative integer", item)
+		}
+		result[td.Name()] = limit
+	}
+	return result, nil
+}
+
+type entry struct {
+	id       string
+	services []string
+}
+
+// Quotas keep the numbers of the stored network service endpoints by the SPIFFE ID registered them and by network
+// service. The SPIFFE ID is the subject of the first path token. The endpoints restored from a trusted source are
+// charged to the first path id they were stored with, they are counted but never rejected.
+type Quotas struct {
+	limits            Limits
+	trustDomainLimits map[string]int
+	onExceeded        func(scope string)
+
+	mu        sync.Mutex
+	entries   map[string]*entry
+	byID      map[string]int
+	byService map[string]int
+}
+
+// New creates new Quotas, the endpoints are not limited by default
+func New(options ...Option) *Quotas {
+	q := &Quotas{
+		trustDomainLimits: make(map[string]int),
+		onExceeded:        func(string) {},
+		entries:           make(map[string]*entry),
+		byID:              make(map[string]int),
+		byService:         make(map[string]int),
+	}
+	for _, opt := range options {
+		opt(q)
+	}
+	return q
+}
+
+// Usage calls f with the scope, the subject, the number of the stored endpoints and the limit of the quota of the
+// total number and of each SPIFFE ID and network service having endpoints
+func (q *Quotas) Usage(f func(scope, subject string, used, limit int)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	f(ScopeTotal, "", len(q.entries), q.limits.Total)
+	for id, count := range q.byID {
+		f(ScopeID, id, count, q.idLimit(id))
+	}
+	for service, count := range q.byService {
+		f(ScopeService, service, count, q.limits.PerService)
+	}
+}
+
+// register charges the quotas of the registered endpoint, it returns RESOURCE_EXHAUSTED status if any of them is
+// exceeded. The refresh of the stored endpoint is charged only for its new SPIFFE ID and network services. The
+// returned function reverts the charge if the registration fails.
+func (q *Quotas) register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (func(), error) {
+	name := nse.GetName()
+	id, trusted := pathID(ctx, nse)
+	e := &entry{id: id, services: unique(nse.GetNetworkServiceNames())}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	old := q.entries[name]
+	if old != nil && e.id == "" {
+		e.id = old.id
+	}
+	if !trusted {
+		if err := q.checkLocked(old, e); err != nil {
+			return nil, err
+		}
+	}
+
+	q.removeLocked(name)
+	q.addLocked(name, e)
+	return func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		if q.entries[name] != e {
+			return
+		}
+		q.removeLocked(name)
+		if old != nil {
+			q.addLocked(name, old)
+		}
+	}, nil
+}
+
+// unregister releases the quotas of the deleted endpoint
+func (q *Quotas) unregister(name string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.removeLocked(name)
+}
+
+func (q *Quotas) checkLocked(old, e *entry) error {
+	var violations []*errdetails.QuotaFailure_Violation
+	exceeded := func(scope, subject string, limit int) {
+		q.onExceeded(scope)
+		description := fmt.Sprintf("quota of %d network service endpoints", limit)
+		if subject != "" {
+			description += fmt.Sprintf(" of %s %s", scope, subject)
+		}
+		violations = append(violations, &errdetails.QuotaFailure_Violation{
+			Subject:     scope + ":" + subject,
+			Description: description + " is exceeded",
+		})
+	}
+
+	if old == nil && q.limits.Total > 0 && len(q.entries) >= q.limits.Total {
+		exceeded(ScopeTotal, "", q.limits.Total)
+	}
+	if limit := q.idLimit(e.id); e.id != "" && (old == nil || old.id != e.id) && limit > 0 && q.byID[e.id] >= limit {
+		exceeded(ScopeID, e.id, limit)
+	}
+	for _, service := range e.services {
+		if old != nil && contains(old.services, service) {
+			continue
+		}
+		if q.limits.PerService > 0 && q.byService[service] >= q.limits.PerService {
+			exceeded(ScopeService, service, q.limits.PerService)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.ResourceExhausted, violations[0].GetDescription())
+	if withDetails, err := st.WithDetails(&errdetails.QuotaFailure{Violations: violations}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// idLimit returns the limit of the SPIFFE ID overridden by its trust domain
+func (q *Quotas) idLimit(id string) int {
+	if spiffeID, err := spiffeid.FromString(id); err == nil {
+		if limit, ok := q.trustDomainLimits[spiffeID.TrustDomain().Name()]; ok {
+			return limit
+		}
+	}
+	return q.limits.PerID
+}
+
+func (q *Quotas) addLocked(name string, e *entry) {
+	q.entries[name] = e
+	if e.id != "" {
+		q.byID[e.id]++
+	}
+	for _, service := range e.services {
+		q.byService[service]++
+	}
+}
+
+func (q *Quotas) removeLocked(name string) {
+	e, ok := q.entries[name]
+	if !ok {
+		return
+	}
+	delete(q.entries, name)
+	if e.id != "" {
+		if q.byID[e.id]--; q.byID[e.id] <= 0 {
+			delete(q.byID, e.id)
+		}
+	}
+	for _, service := range e.services {
+		if q.byService[service]--; q.byService[service] <= 0 {
+			delete(q.byService, service)
+		}
+	}
+}
+
+// pathID returns the subject of the first path token, or of the peer token if the request has no path. The path
+// and the peer are authorized by the time the endpoint reaches the quotas.
+//
+// Only the internal requests of registryserver.Server Restore and Remove are trusted: the restore of the storage, the
+// snapshot and the write-ahead log on startup, and the replication of the entries by antientropy and standby. Their
+// endpoints were admitted by the registry which stored them first, so they are counted but never rejected. They are
+// charged to the first path id of the endpoint, the SPIFFE ID of the client registered it. The requests of the clients
+// are never trusted, even if they have neither the path nor the token.
+func pathID(ctx context.Context, nse *registry.NetworkServiceEndpoint) (id string, trusted bool) {
+	if registryserver.IsInternal(ctx) {
+		if pathIDs := nse.GetPathIds(); len(pathIDs) > 0 {
+			return pathIDs[0], true
+		}
+		return "", true
+	}
+	var tok string
+	if path := grpcmetadata.PathFromContext(ctx); len(path.PathSegments) > 0 {
+		tok = path.PathSegments[0].Token
+	} else if peerTok, _, err := token.FromContext(ctx); err == nil {
+		tok = peerTok
+	}
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tok, &claims); err != nil {
+		return "", false
+	}
+	sub, _ := claims["sub"].(string)
+	return sub, false
+}
+
+func unique(items []string) []string {
+	result := append([]string(nil), items...)
+	sort.Strings(result)
+	n := 0
+	for i, item := range result {
+		if i == 0 || item != result[n-1] {
+			result[n] = item
+			n++
+		}
+	}
+	return result[:n]
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/memory"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/quota"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+)
+
+func withPathID(t *testing.T, id string) context.Context {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": id}).SignedString([]byte("key"))
+	require.NoError(t, err)
+	return grpcmetadata.PathWithContext(context.Background(), &grpcmetadata.Path{
+		PathSegments: []*grpcmetadata.PathSegment{{Token: token}},
+	})
+}
+
+func usage(q *quota.Quotas) map[string]int {
+	result := make(map[string]int)
+	q.Usage(func(scope, subject string, used, _ int) {
+		result[scope+":"+subject] = used
+	})
+	return result
+}
+
+func TestQuotas(t *testing.T) {
+	var exceeded []string
+	q := quota.New(
+		quota.WithLimits(quota.Limits{PerID: 2, PerService: 3, Total: 4}),
+		quota.WithTrustDomainLimit("other.org", 1),
+		quota.WithOnExceeded(func(scope string) {
+			exceeded = append(exceeded, scope)
+		}),
+	)
+	server := next.NewNetworkServiceEndpointRegistryServer(
+		quota.NewNetworkServiceEndpointRegistryServer(q),
+		memory.NewNetworkServiceEndpointRegistryServer(),
+	)
+	register := func(ctx context.Context, name string, services ...string) error {
+		_, err := server.Register(ctx, &registry.NetworkServiceEndpoint{Name: name, NetworkServiceNames: services})
+		return err
+	}
+
+	ctxA := withPathID(t, "spiffe://example.org/nse-a")
+	ctxB := withPathID(t, "spiffe://example.org/nse-b")
+	ctxC := withPathID(t, "spiffe://other.org/nse-c")
+
+	require.NoError(t, register(ctxA, "nse-1", "ns-1"))
+	require.NoError(t, register(ctxA, "nse-2", "ns-1"))
+
+	// The refresh is not charged again
+	require.NoError(t, register(ctxA, "nse-2", "ns-1"))
+
+	err := register(ctxA, "nse-3", "ns-2")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Len(t, status.Convert(err).Details(), 1)
+	failure, ok := status.Convert(err).Details()[0].(*errdetails.QuotaFailure)
+	require.True(t, ok)
+	require.Equal(t, "spiffe_id:spiffe://example.org/nse-a", failure.GetViolations()[0].GetSubject())
+
+	// The limit of the SPIFFE IDs is overridden by the trust domain
+	require.NoError(t, register(ctxC, "nse-4", "ns-1"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(register(ctxC, "nse-5", "ns-2")))
+
+	require.Equal(t, codes.ResourceExhausted, status.Code(register(ctxB, "nse-6", "ns-1")))
+	require.NoError(t, register(ctxB, "nse-6", "ns-2"))
+	require.Equal(t, codes.ResourceExhausted, status.Code(register(ctxB, "nse-7", "ns-3")))
+	require.Equal(t, []string{quota.ScopeID, quota.ScopeID, quota.ScopeService, quota.ScopeTotal}, exceeded)
+
+	require.Equal(t, map[string]int{
+		"total:":                               4,
+		"spiffe_id:spiffe://example.org/nse-a": 2,
+		"spiffe_id:spiffe://example.org/nse-b": 1,
+		"spiffe_id:spiffe://other.org/nse-c":   1,
+		"network_service:ns-1":                 3,
+		"network_service:ns-2":                 1,
+	}, usage(q))
+
+	// The quotas are released by the unregistration
+	_, err = server.Unregister(ctxA, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	require.NoError(t, register(ctxA, "nse-3", "ns-2"))
+}
+
+// serve serves the registry and returns the client of the network service endpoints connected to it
+func serve(ctx context.Context, t *testing.T, r *registryserver.Server) registry.NetworkServiceEndpointRegistryClient {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	r.Register(server)
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(server.Stop)
+
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+	return registry.NewNetworkServiceEndpointRegistryClient(cc)
+}
+
+// withPeerToken returns the context of the client sending the token of the id and no path
+func withPeerToken(ctx context.Context, t *testing.T, id string) context.Context {
+	expireTime := time.Now().Add(time.Hour)
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   id,
+		ExpiresAt: jwt.NewNumericDate(expireTime),
+	}).SignedString([]byte("key"))
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(ctx,
+		"nsm-client-token", tok,
+		"nsm-client-token-expires", expireTime.Format(time.RFC3339Nano))
+}
+
+func TestQuotas_Restore(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	q := quota.New(quota.WithLimits(quota.Limits{Total: 1}))
+	r := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithNSERegistryServers(quota.NewNetworkServiceEndpointRegistryServer(q)))
+
+	// The restored endpoints are stored regardless of the quotas but counted
+	require.NoError(t, r.Restore(ctx, nil, []*registry.NetworkServiceEndpoint{{Name: "nse-1"}, {Name: "nse-2"}}))
+	require.Equal(t, map[string]int{"total:": 2}, usage(q))
+
+	nseClient := serve(ctx, t, r)
+	clientCtx := withPeerToken(ctx, t, "spiffe://example.org/nse")
+	_, err := nseClient.Register(clientCtx, &registry.NetworkServiceEndpoint{Name: "nse-3"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The restored endpoint is refreshed by its owner
+	_, err = nseClient.Register(clientCtx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"total:": 2, "spiffe_id:spiffe://example.org/nse": 1}, usage(q))
+}
+
+func TestQuotas_Restart(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	registryStorage := storage.NewMemory()
+	newServer := func() (*quota.Quotas, registry.NetworkServiceEndpointRegistryClient) {
+		q := quota.New(quota.WithLimits(quota.Limits{PerID: 1}))
+		r := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+			registryserver.WithStorage(registryStorage),
+			registryserver.WithNSERegistryServers(quota.NewNetworkServiceEndpointRegistryServer(q)))
+		require.NoError(t, storage.Restore(ctx, registryStorage, r))
+		return q, serve(ctx, t, r)
+	}
+
+	clientCtx := withPeerToken(ctx, t, "spiffe://example.org/nse")
+	_, nseClient := newServer()
+	_, err := nseClient.Register(clientCtx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+
+	// The restored endpoint is charged to the SPIFFE ID registered it before the restart
+	q, nseClient := newServer()
+	require.Equal(t, map[string]int{"total:": 1, "spiffe_id:spiffe://example.org/nse": 1}, usage(q))
+	_, err = nseClient.Register(clientCtx, &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestQuotas_ClientWithoutPath(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	q := quota.New(quota.WithLimits(quota.Limits{PerID: 1, Total: 2}))
+	r := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithNSERegistryServers(quota.NewNetworkServiceEndpointRegistryServer(q)))
+	nseClient := serve(ctx, t, r)
+
+	// The client sending no path is charged by its token
+	clientCtx := withPeerToken(ctx, t, "spiffe://example.org/nse")
+	_, err := nseClient.Register(clientCtx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+	_, err = nseClient.Register(clientCtx, &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The client sending neither the path nor the token is not trusted either
+	_, err = nseClient.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-3"})
+	require.NoError(t, err)
+	_, err = nseClient.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-4"})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, map[string]int{"total:": 2, "spiffe_id:spiffe://example.org/nse": 1}, usage(q))
+}
+
+func TestParseTrustDomainLimits(t *testing.T) {
+	limits, err := quota.ParseTrustDomainLimits([]string{"example.org=10", "other.org=0"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"example.org": 10, "other.org": 0}, limits)
+
+	for _, item := range []string{"example.org", "=10", "example.org=-1", "example.org=many", "Example Org=1"} {
+		_, err = quota.ParseTrustDomainLimits([]string{item})
+		require.Error(t, err, item)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+// Option modifies the limiter option value
+type Option func(l *Limiter)
+
+// WithRegisterLimit sets the limit of the registrations of each caller
+func WithRegisterLimit(limit Limit) Option {
+	return func(l *Limiter) {
+		l.limits[registerMethod] = limit
+	}
+}
+
+// WithFindLimit sets the limit of the finds of each caller
+func WithFindLimit(limit Limit) Option {
+	return func(l *Limiter) {
+		l.limits[findMethod] = limit
+	}
+}
+
+// WithByService limits the requests of each caller for each network service separately
+func WithByService(byService bool) Option {
+	return func(l *Limiter) {
+		l.byService = byService
+	}
+}
+
+// WithOnThrottled sets the function called with the resource and the method of each throttled request
+func WithOnThrottled(onThrottled func(resource, method string)) Option {
+	return func(l *Limiter) {
+		l.onThrottled = onThrottled
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides the registry chain elements limiting the rate of registrations and finds of each caller
+// by token buckets
+package ratelimit
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/peerauth"
+)
+
+const (
+	registerMethod = "Register"
+	findMethod     = "Find"
+
+	nsResource  = "ns"
+	nseResource = "nse"
+
+	// cleanupInterval is the interval between the removals of the idle buckets
+	cleanupInterval = time.Minute
+)
+
+// Limit is the rate and the burst of the token bucket, the requests are not limited if the rate is 0
+type Limit struct {
+	// Rate is the number of requests per second
+	Rate float64
+	// Burst is the maximum number of requests at once
+	Burst int
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// Limiter keeps the token buckets of the callers. The callers are identified by the SPIFFE ID of the certificate
+// they present, the callers without it share the same buckets.
+type Limiter struct {
+	limits      map[string]Limit
+	byService   bool
+	onThrottled func(resource, method string)
+
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+// New creates a new Limiter, the requests are not limited by default
+func New(options ...Option) *Limiter {
+	l := &Limiter{
+		limits:      make(map[string]Limit),
+		onThrottled: func(string, string) {},
+		buckets:     make(map[string]*bucket),
+		lastCleanup: time.Now(),
+	}
+	for _, opt := range options {
+		opt(l)
+	}
+	return l
+}
+
+// allow takes a token from the bucket of the caller, it returns RESOURCE_EXHAUSTED status if the bucket is empty
+func (l *Limiter) allow(ctx context.Context, resource, method string, services []string) error {
+	limit := l.limits[method]
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	var caller string
+	if id, err := peerauth.PeerID(ctx); err == nil {
+		caller = id.String()
+	}
+	key := method + " " + caller
+	if l.byService {
+		services = append([]string(nil), services...)
+		sort.Strings(services)
+		key += " " + strings.Join(services, ",")
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.cleanupLocked(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	allowed := b.limiter.AllowN(now, 1)
+	l.mu.Unlock()
+
+	if allowed {
+		return nil
+	}
+	l.onThrottled(resource, method)
+	if caller == "" {
+		caller = "anonymous caller"
+	}
+	return status.Errorf(codes.ResourceExhausted, "rate limit of %s requests is exceeded for %s", method, caller)
+}
+
+// cleanupLocked removes the buckets idle long enough to be full again, they are the same as the new ones
+func (l *Limiter) cleanupLocked(now time.Time) {
+	if now.Sub(l.lastCleanup) < cleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+	for key, b := range l.buckets {
+		if b.limiter.TokensAt(now) >= float64(b.limiter.Burst()) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/ratelimit"
+)
+
+func withPeerID(ctx context.Context, id string) context.Context {
+	u, _ := url.Parse(id)
+	return peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{{URIs: []*url.URL{u}}}},
+		},
+	})
+}
+
+func register(ctx context.Context, server registry.NetworkServiceEndpointRegistryServer, ns string) codes.Code {
+	_, err := server.Register(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1", NetworkServiceNames: []string{ns}})
+	return status.Code(err)
+}
+
+func TestLimiter(t *testing.T) {
+	var throttled []string
+	limiter := ratelimit.New(
+		ratelimit.WithRegisterLimit(ratelimit.Limit{Rate: 0.001, Burst: 2}),
+		ratelimit.WithOnThrottled(func(resource, method string) {
+			throttled = append(throttled, resource+" "+method)
+		}),
+	)
+	server := next.NewNetworkServiceEndpointRegistryServer(ratelimit.NewNetworkServiceEndpointRegistryServer(limiter))
+
+	ctxA := withPeerID(context.Background(), "spiffe://example.org/nse-a")
+	ctxB := withPeerID(context.Background(), "spiffe://example.org/nse-b")
+
+	require.Equal(t, codes.OK, register(ctxA, server, "ns-1"))
+	require.Equal(t, codes.OK, register(ctxA, server, "ns-2"))
+	require.Equal(t, codes.ResourceExhausted, register(ctxA, server, "ns-1"))
+	require.Equal(t, []string{"nse Register"}, throttled)
+
+	// The buckets are kept for each caller
+	require.Equal(t, codes.OK, register(ctxB, server, "ns-1"))
+
+	// Finds are not limited without the find limit
+	for i := 0; i < 5; i++ {
+		require.NoError(t, server.Find(&registry.NetworkServiceEndpointQuery{
+			NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+		}, streamchannel.NewNetworkServiceEndpointFindServer(ctxA, make(chan *registry.NetworkServiceEndpointResponse, 1))))
+	}
+}
+
+func TestLimiter_ByService(t *testing.T) {
+	limiter := ratelimit.New(
+		ratelimit.WithFindLimit(ratelimit.Limit{Rate: 0.001, Burst: 1}),
+		ratelimit.WithByService(true),
+	)
+	server := next.NewNetworkServiceRegistryServer(ratelimit.NewNetworkServiceRegistryServer(limiter))
+	find := func(ctx context.Context, ns string) codes.Code {
+		return status.Code(server.Find(&registry.NetworkServiceQuery{
+			NetworkService: &registry.NetworkService{Name: ns},
+		}, streamchannel.NewNetworkServiceFindServer(ctx, make(chan *registry.NetworkServiceResponse, 1))))
+	}
+
+	ctx := withPeerID(context.Background(), "spiffe://example.org/nsmgr")
+	require.Equal(t, codes.OK, find(ctx, "ns-1"))
+	require.Equal(t, codes.ResourceExhausted, find(ctx, "ns-1"))
+	require.Equal(t, codes.OK, find(ctx, "ns-2"))
+
+	// The callers without SPIFFE ID share the buckets
+	require.Equal(t, codes.OK, find(context.Background(), "ns-1"))
+	require.Equal(t, codes.ResourceExhausted, find(context.Background(), "ns-1"))
+
+	_, err := server.Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+type rateLimitNSServer struct {
+	limiter *Limiter
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer chain element limiting the rate of the
+// network service registrations and finds
+func NewNetworkServiceRegistryServer(l *Limiter) registry.NetworkServiceRegistryServer {
+	return &rateLimitNSServer{
+		limiter: l,
+	}
+}
+
+func (s *rateLimitNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if err := s.limiter.allow(ctx, nsResource, registerMethod, []string{ns.GetName()}); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *rateLimitNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if err := s.limiter.allow(server.Context(), nsResource, findMethod, []string{query.GetNetworkService().GetName()}); err != nil {
+		return err
+	}
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *rateLimitNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+type rateLimitNSEServer struct {
+	limiter *Limiter
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element limiting
+// the rate of the network service endpoint registrations and finds
+func NewNetworkServiceEndpointRegistryServer(l *Limiter) registry.NetworkServiceEndpointRegistryServer {
+	return &rateLimitNSEServer{
+		limiter: l,
+	}
+}
+
+func (s *rateLimitNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if err := s.limiter.allow(ctx, nseResource, registerMethod, nse.GetNetworkServiceNames()); err != nil {
+		return nil, err
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *rateLimitNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	services := query.GetNetworkServiceEndpoint().GetNetworkServiceNames()
+	if err := s.limiter.allow(server.Context(), nseResource, findMethod, services); err != nil {
+		return err
+	}
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *rateLimitNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registryserver
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/clock"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/expiry"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/ttl"
+)
+
+type expirationNSEServer struct {
+	defaultExpiration atomic.Int64
+	maxExpiration     time.Duration
+	ttlPolicies       []*ttl.Policy
+}
+
+// newExpirationNSEServer creates a new NetworkServiceEndpointRegistryServer chain element that sets the default
+// expiration time of the endpoints registered without one and limits it by the max expiration. The expirations are
+// overridden by the first TTL policy matching the endpoint, but they can't exceed the max expiration.
+func newExpirationNSEServer(defaultExpiration, maxExpiration time.Duration, ttlPolicies []*ttl.Policy) *expirationNSEServer {
+	s := &expirationNSEServer{
+		maxExpiration: maxExpiration,
+		ttlPolicies:   ttlPolicies,
+	}
+	s.defaultExpiration.Store(int64(defaultExpiration))
+	return s
+}
+
+// setDefaultExpiration changes the default expiration of the endpoints registered after the call
+func (s *expirationNSEServer) setDefaultExpiration(d time.Duration) error {
+	if d <= 0 || d > s.maxExpiration {
+		return errors.Errorf("default expiration %v should be positive and not exceed max expiration %v", d, s.maxExpiration)
+	}
+	s.defaultExpiration.Store(int64(d))
+	return nil
+}
+
+// expirations returns the default and max expirations of the endpoint
+func (s *expirationNSEServer) expirations(nse *registry.NetworkServiceEndpoint) (defaultExpiration, maxExpiration time.Duration) {
+	defaultExpiration, maxExpiration = time.Duration(s.defaultExpiration.Load()), s.maxExpiration
+	if policy := ttl.Select(s.ttlPolicies, nse); policy != nil {
+		if policy.DefaultExpiration > 0 {
+			defaultExpiration = time.Duration(policy.DefaultExpiration)
+		}
+		if policy.MaxExpiration > 0 && time.Duration(policy.MaxExpiration) < maxExpiration {
+			maxExpiration = time.Duration(policy.MaxExpiration)
+		}
+	}
+	return defaultExpiration, maxExpiration
+}
+
+func (s *expirationNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	now := clock.FromContext(ctx).Now()
+	defaultExpiration, maxExpiration := s.expirations(nse)
+	if nse.GetExpirationTime() == nil && defaultExpiration > 0 {
+		nse.ExpirationTime = timestamppb.New(now.Add(defaultExpiration))
+	}
+	if maxExpirationTime := now.Add(maxExpiration); nse.GetExpirationTime() == nil || nse.GetExpirationTime().AsTime().After(maxExpirationTime) {
+		nse.ExpirationTime = timestamppb.New(maxExpirationTime)
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *expirationNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *expirationNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
+
+// SetDefaultExpiration changes the default expiration of the endpoints registered without expiration time, it can't
+// exceed the max expiration
+func (s *Server) SetDefaultExpiration(d time.Duration) error {
+	return s.expiration.setDefaultExpiration(d)
+}
+
+// SetExpirePeriod changes the period to check the stored endpoints for expiration, the check is disabled if it is 0
+func (s *Server) SetExpirePeriod(d time.Duration) {
+	s.sweepMu.Lock()
+	defer s.sweepMu.Unlock()
+
+	if s.sweepCancel != nil {
+		s.sweepCancel()
+		s.sweepCancel = nil
+	}
+	if d > 0 {
+		var ctx context.Context
+		ctx, s.sweepCancel = context.WithCancel(s.ctx)
+		go s.sweepExpired(ctx, d)
+	}
+}
+
+// sweepExpired periodically unregisters the stored endpoints whose expiration time has passed. The endpoints are
+// expired by the timers of the chain, the sweep catches the ones stored bypassing it, e.g. replicated by the cluster.
+// In the cluster only the leader sweeps, the unregistrations are replicated to the other nodes. The endpoints are
+// expired by the expiration time they are found with, so the ones refreshed since then are kept.
+func (s *Server) sweepExpired(ctx context.Context, period time.Duration) {
+	timeClock := clock.FromContext(ctx)
+	ticker := timeClock.Ticker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			if !s.isLeader() {
+				continue
+			}
+			stream, err := adapters.NetworkServiceEndpointServerToClient(s.localNSEChain).Find(ctx, &registry.NetworkServiceEndpointQuery{
+				NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+			})
+			if err != nil {
+				log.FromContext(ctx).Errorf("failed to find expired network service endpoints: %s", err.Error())
+				continue
+			}
+			for _, nse := range registry.ReadNetworkServiceEndpointList(stream) {
+				if nse.GetExpirationTime() == nil || timeClock.Now().Before(nse.GetExpirationTime().AsTime()) {
+					continue
+				}
+				expireCtx := expiry.WithExpirationTime(ctx, nse.GetExpirationTime())
+				if _, err := s.localNSEChain.Unregister(expireCtx, nse); err != nil && !errors.Is(err, storage.ErrNotExpired) {
+					log.FromContext(ctx).Errorf("failed to unregister expired network service endpoint %s: %s", nse.GetName(), err.Error())
+				}
+			}
+		}
+	}
+}
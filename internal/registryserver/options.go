@@ -0,0 +1,205 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registryserver
+
+import (
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/ttl"
+)
+
+type serverOptions struct {
+	authorizeNSRegistryServer  registry.NetworkServiceRegistryServer
+	authorizeNSERegistryServer registry.NetworkServiceEndpointRegistryServer
+	authorizeNSRegistryClient  registry.NetworkServiceRegistryClient
+	authorizeNSERegistryClient registry.NetworkServiceEndpointRegistryClient
+	defaultExpiration          time.Duration
+	maxExpiration              time.Duration
+	expirePeriod               time.Duration
+	isLeader                   func() bool
+	ttlPolicies                []*ttl.Policy
+	gracePeriod                time.Duration
+	tombstoneTTL               time.Duration
+	proxyRegistryURL           *url.URL
+	dialOptions                []grpc.DialOption
+	nsRegistryServers          []registry.NetworkServiceRegistryServer
+	nseRegistryServers         []registry.NetworkServiceEndpointRegistryServer
+	nsFrontServers             []registry.NetworkServiceRegistryServer
+	nseFrontServers            []registry.NetworkServiceEndpointRegistryServer
+	storage                    storage.Storage
+}
+
+// Option modifies server option value
+type Option func(o *serverOptions)
+
+// WithAuthorizeNSRegistryServer sets authorization NetworkServiceRegistry chain element
+func WithAuthorizeNSRegistryServer(authorizeNSRegistryServer registry.NetworkServiceRegistryServer) Option {
+	if authorizeNSRegistryServer == nil {
+		panic("authorizeNSRegistryServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSRegistryServer = authorizeNSRegistryServer
+	}
+}
+
+// WithAuthorizeNSERegistryServer sets authorization NetworkServiceEndpointRegistry chain element
+func WithAuthorizeNSERegistryServer(authorizeNSERegistryServer registry.NetworkServiceEndpointRegistryServer) Option {
+	if authorizeNSERegistryServer == nil {
+		panic("authorizeNSERegistryServer cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSERegistryServer = authorizeNSERegistryServer
+	}
+}
+
+// WithAuthorizeNSRegistryClient sets authorization NetworkServiceRegistry chain element
+func WithAuthorizeNSRegistryClient(authorizeNSRegistryClient registry.NetworkServiceRegistryClient) Option {
+	if authorizeNSRegistryClient == nil {
+		panic("authorizeNSRegistryClient cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSRegistryClient = authorizeNSRegistryClient
+	}
+}
+
+// WithAuthorizeNSERegistryClient sets authorization NetworkServiceEndpointRegistry chain element
+func WithAuthorizeNSERegistryClient(authorizeNSERegistryClient registry.NetworkServiceEndpointRegistryClient) Option {
+	if authorizeNSERegistryClient == nil {
+		panic("authorizeNSERegistryClient cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.authorizeNSERegistryClient = authorizeNSERegistryClient
+	}
+}
+
+// WithDefaultExpiration sets the default expiration for endpoints registered without expiration time
+func WithDefaultExpiration(d time.Duration) Option {
+	return func(o *serverOptions) {
+		o.defaultExpiration = d
+	}
+}
+
+// WithMaxExpiration sets the maximum expiration for endpoints
+func WithMaxExpiration(d time.Duration) Option {
+	if d <= 0 {
+		panic("max expiration must be positive")
+	}
+	return func(o *serverOptions) {
+		o.maxExpiration = d
+	}
+}
+
+// WithTTLPolicies sets the policies overriding the default and max expirations for endpoints of the matching network
+// services, the first matching policy is applied
+func WithTTLPolicies(policies ...*ttl.Policy) Option {
+	return func(o *serverOptions) {
+		o.ttlPolicies = policies
+	}
+}
+
+// WithGracePeriod sets the period the expired endpoints are kept stale before the deletion, see grace package
+func WithGracePeriod(d time.Duration) Option {
+	return func(o *serverOptions) {
+		o.gracePeriod = d
+	}
+}
+
+// WithTombstoneTTL sets the period the tombstones of the deleted expired endpoints are kept for, see grace package
+func WithTombstoneTTL(d time.Duration) Option {
+	return func(o *serverOptions) {
+		o.tombstoneTTL = d
+	}
+}
+
+// WithExpirePeriod sets the period to check the stored endpoints for expiration, the check is disabled if it is 0
+func WithExpirePeriod(d time.Duration) Option {
+	return func(o *serverOptions) {
+		o.expirePeriod = d
+	}
+}
+
+// WithLeader sets the function reporting if the registry is the leader of its cluster, only the leader checks the
+// stored endpoints for expiration. By default, the registry is the only one and always checks them.
+func WithLeader(isLeader func() bool) Option {
+	if isLeader == nil {
+		panic("isLeader cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.isLeader = isLeader
+	}
+}
+
+// WithProxyRegistryURL sets URL to reach the proxy registry
+func WithProxyRegistryURL(proxyRegistryURL *url.URL) Option {
+	return func(o *serverOptions) {
+		o.proxyRegistryURL = proxyRegistryURL
+	}
+}
+
+// WithDialOptions sets grpc.DialOptions for the server
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *serverOptions) {
+		o.dialOptions = dialOptions
+	}
+}
+
+// WithNSRegistryServers adds chain elements handling locally stored network services right before the storage
+func WithNSRegistryServers(servers ...registry.NetworkServiceRegistryServer) Option {
+	return func(o *serverOptions) {
+		o.nsRegistryServers = append(o.nsRegistryServers, servers...)
+	}
+}
+
+// WithNSERegistryServers adds chain elements handling locally stored network service endpoints right before the
+// storage
+func WithNSERegistryServers(servers ...registry.NetworkServiceEndpointRegistryServer) Option {
+	return func(o *serverOptions) {
+		o.nseRegistryServers = append(o.nseRegistryServers, servers...)
+	}
+}
+
+// WithNSFrontServers adds chain elements handling incoming network service requests before the path update and
+// authorization
+func WithNSFrontServers(servers ...registry.NetworkServiceRegistryServer) Option {
+	return func(o *serverOptions) {
+		o.nsFrontServers = append(o.nsFrontServers, servers...)
+	}
+}
+
+// WithNSEFrontServers adds chain elements handling incoming network service endpoint requests before the path update
+// and authorization
+func WithNSEFrontServers(servers ...registry.NetworkServiceEndpointRegistryServer) Option {
+	return func(o *serverOptions) {
+		o.nseFrontServers = append(o.nseFrontServers, servers...)
+	}
+}
+
+// WithStorage sets the storage keeping local network services and network service endpoints
+func WithStorage(s storage.Storage) Option {
+	if s == nil {
+		panic("storage cannot be nil")
+	}
+	return func(o *serverOptions) {
+		o.storage = s
+	}
+}
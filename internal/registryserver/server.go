@@ -0,0 +1,286 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registryserver provides the registry chain served by cmd-registry-memory. It follows the memory chain
+// from the sdk but keeps local entries in a pluggable storage and gives access to them, so they can be persisted
+// and restored.
+package registryserver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api"
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	sdkregistry "github.com/networkservicemesh/sdk/pkg/registry"
+	registryauthorize "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/connect"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/dial"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/expire"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/setpayload"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/setregistrationtime"
+	"github.com/networkservicemesh/sdk/pkg/registry/common/updatepath"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/registry/switchcase"
+	"github.com/networkservicemesh/sdk/pkg/registry/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/interdomain"
+	"github.com/networkservicemesh/sdk/pkg/tools/token"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/expiry"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/grace"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/healthcheck"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+)
+
+// Server is a registry server based on the storage
+type Server struct {
+	sdkregistry.Registry
+	localNSChain  registry.NetworkServiceRegistryServer
+	localNSEChain registry.NetworkServiceEndpointRegistryServer
+	health        *healthcheck.Health
+	expiration    *expirationNSEServer
+	isLeader      func() bool
+
+	ctx         context.Context
+	sweepMu     sync.Mutex
+	sweepCancel context.CancelFunc
+}
+
+// NewServer creates new registry server based on the storage, memory storage is used by default
+func NewServer(ctx context.Context, tokenGenerator token.GeneratorFunc, options ...Option) *Server {
+	opts := &serverOptions{
+		authorizeNSRegistryServer:  registryauthorize.NewNetworkServiceRegistryServer(registryauthorize.Any()),
+		authorizeNSERegistryServer: registryauthorize.NewNetworkServiceEndpointRegistryServer(registryauthorize.Any()),
+		authorizeNSRegistryClient:  registryauthorize.NewNetworkServiceRegistryClient(registryauthorize.Any()),
+		authorizeNSERegistryClient: registryauthorize.NewNetworkServiceEndpointRegistryClient(registryauthorize.Any()),
+		defaultExpiration:          time.Minute,
+		maxExpiration:              time.Hour,
+		proxyRegistryURL:           nil,
+		isLeader:                   func() bool { return true },
+		storage:                    storage.NewMemory(),
+	}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	expiration := newExpirationNSEServer(opts.defaultExpiration, opts.maxExpiration, opts.ttlPolicies)
+
+	var nseStorageServers []registry.NetworkServiceEndpointRegistryServer
+	if opts.gracePeriod > 0 || opts.tombstoneTTL > 0 {
+		nseStorageServers = append(nseStorageServers, grace.NewNetworkServiceEndpointRegistryServer(
+			grace.WithGracePeriod(opts.gracePeriod),
+			grace.WithTombstoneTTL(opts.tombstoneTTL),
+		))
+	}
+	nseStorageServers = append(nseStorageServers,
+		setregistrationtime.NewNetworkServiceEndpointRegistryServer(),
+		expiration,
+		expiry.NewNetworkServiceEndpointRegistryServer(),
+		// The expire chain element limits the expiration by its default one and the tokens expiration
+		expire.NewNetworkServiceEndpointRegistryServer(ctx, expire.WithDefaultExpiration(opts.maxExpiration)),
+	)
+	nseStorageServers = append(nseStorageServers, opts.nseRegistryServers...)
+	nseStorageServers = append(nseStorageServers, storage.NewNetworkServiceEndpointRegistryServer(opts.storage))
+
+	localNSEChain := chain.NewNetworkServiceEndpointRegistryServer(
+		begin.NewNetworkServiceEndpointRegistryServer(),
+		metadata.NewNetworkServiceEndpointServer(),
+		switchcase.NewNetworkServiceEndpointRegistryServer(switchcase.NSEServerCase{
+			Condition: func(c context.Context, nse *registry.NetworkServiceEndpoint) bool {
+				if interdomain.Is(nse.GetName()) {
+					return true
+				}
+				for _, ns := range nse.GetNetworkServiceNames() {
+					if interdomain.Is(ns) {
+						return true
+					}
+				}
+				return false
+			},
+			Action: chain.NewNetworkServiceEndpointRegistryServer(
+				connect.NewNetworkServiceEndpointRegistryServer(
+					chain.NewNetworkServiceEndpointRegistryClient(
+						begin.NewNetworkServiceEndpointRegistryClient(),
+						clienturl.NewNetworkServiceEndpointRegistryClient(opts.proxyRegistryURL),
+						clientconn.NewNetworkServiceEndpointRegistryClient(),
+						opts.authorizeNSERegistryClient,
+						grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+						dial.NewNetworkServiceEndpointRegistryClient(ctx,
+							dial.WithDialOptions(opts.dialOptions...),
+						),
+						connect.NewNetworkServiceEndpointRegistryClient(),
+					),
+				),
+			),
+		},
+			switchcase.NSEServerCase{
+				Condition: func(c context.Context, nse *registry.NetworkServiceEndpoint) bool { return true },
+				Action:    chain.NewNetworkServiceEndpointRegistryServer(nseStorageServers...),
+			},
+		),
+	)
+	var nseServers []registry.NetworkServiceEndpointRegistryServer
+	nseServers = append(nseServers, grpcmetadata.NewNetworkServiceEndpointRegistryServer())
+	nseServers = append(nseServers, opts.nseFrontServers...)
+	nseServers = append(nseServers,
+		updatepath.NewNetworkServiceEndpointRegistryServer(tokenGenerator),
+		opts.authorizeNSERegistryServer,
+		localNSEChain,
+	)
+	nseChain := chain.NewNetworkServiceEndpointRegistryServer(nseServers...)
+
+	var nsStorageServers []registry.NetworkServiceRegistryServer
+	nsStorageServers = append(nsStorageServers, opts.nsRegistryServers...)
+	nsStorageServers = append(nsStorageServers, storage.NewNetworkServiceRegistryServer(opts.storage))
+
+	localNSChain := chain.NewNetworkServiceRegistryServer(
+		metadata.NewNetworkServiceServer(),
+		setpayload.NewNetworkServiceRegistryServer(),
+		switchcase.NewNetworkServiceRegistryServer(
+			switchcase.NSServerCase{
+				Condition: func(c context.Context, ns *registry.NetworkService) bool {
+					return interdomain.Is(ns.GetName())
+				},
+				Action: connect.NewNetworkServiceRegistryServer(
+					chain.NewNetworkServiceRegistryClient(
+						clienturl.NewNetworkServiceRegistryClient(opts.proxyRegistryURL),
+						begin.NewNetworkServiceRegistryClient(),
+						clientconn.NewNetworkServiceRegistryClient(),
+						opts.authorizeNSRegistryClient,
+						grpcmetadata.NewNetworkServiceRegistryClient(),
+						dial.NewNetworkServiceRegistryClient(ctx,
+							dial.WithDialOptions(opts.dialOptions...),
+						),
+						connect.NewNetworkServiceRegistryClient(),
+					),
+				),
+			},
+			switchcase.NSServerCase{
+				Condition: func(c context.Context, ns *registry.NetworkService) bool {
+					return true
+				},
+				Action: chain.NewNetworkServiceRegistryServer(nsStorageServers...),
+			},
+		),
+	)
+	var nsServers []registry.NetworkServiceRegistryServer
+	nsServers = append(nsServers, grpcmetadata.NewNetworkServiceRegistryServer())
+	nsServers = append(nsServers, opts.nsFrontServers...)
+	nsServers = append(nsServers,
+		updatepath.NewNetworkServiceRegistryServer(tokenGenerator),
+		opts.authorizeNSRegistryServer,
+		localNSChain,
+	)
+	nsChain := chain.NewNetworkServiceRegistryServer(nsServers...)
+
+	registryServer := sdkregistry.NewServer(nsChain, nseChain)
+	var services []string
+	for _, service := range []interface{}{registryServer.NetworkServiceRegistryServer(), registryServer.NetworkServiceEndpointRegistryServer()} {
+		services = append(services, api.ServiceNames(service)...)
+	}
+
+	server := &Server{
+		Registry:      registryServer,
+		localNSChain:  localNSChain,
+		localNSEChain: localNSEChain,
+		health:        healthcheck.New(ctx, services...),
+		expiration:    expiration,
+		isLeader:      opts.isLeader,
+		ctx:           ctx,
+	}
+	server.SetExpirePeriod(opts.expirePeriod)
+	return server
+}
+
+// Register registers the registry services and the health service reporting their readiness. The services are
+// serving until SetServing(false) is called, they are ready if all checked components are healthy.
+func (s *Server) Register(server *grpc.Server) {
+	s.health.Register(server)
+	s.SetServing(true)
+	registry.RegisterNetworkServiceRegistryServer(server, s.NetworkServiceRegistryServer())
+	registry.RegisterNetworkServiceEndpointRegistryServer(server, s.NetworkServiceEndpointRegistryServer())
+}
+
+// SetServing sets if the registry services are serving, the health service reports them as not serving otherwise
+func (s *Server) SetServing(serving bool) {
+	s.health.SetServing(serving)
+}
+
+// Health returns the health of the registry, the checks of its components are added to it
+func (s *Server) Health() *healthcheck.Health {
+	return s.health
+}
+
+// Dump returns all network services and network service endpoints stored by the server
+func (s *Server) Dump(ctx context.Context) ([]*registry.NetworkService, []*registry.NetworkServiceEndpoint, error) {
+	nsStream, err := adapters.NetworkServiceServerToClient(s.localNSChain).Find(ctx, &registry.NetworkServiceQuery{
+		NetworkService: new(registry.NetworkService),
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to find network services")
+	}
+	nseStream, err := adapters.NetworkServiceEndpointServerToClient(s.localNSEChain).Find(ctx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to find network service endpoints")
+	}
+	return registry.ReadNetworkServiceList(nsStream), registry.ReadNetworkServiceEndpointList(nseStream), nil
+}
+
+// Restore stores network services and network service endpoints in the server bypassing path update and
+// authorization, so they should come from a trusted source
+func (s *Server) Restore(ctx context.Context, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error {
+	ctx = withInternal(ctx)
+	for _, ns := range nss {
+		if _, err := s.localNSChain.Register(ctx, ns.Clone()); err != nil {
+			return errors.Wrapf(err, "failed to restore network service %s", ns.GetName())
+		}
+	}
+	for _, nse := range nses {
+		if _, err := s.localNSEChain.Register(ctx, nse.Clone()); err != nil {
+			return errors.Wrapf(err, "failed to restore network service endpoint %s", nse.GetName())
+		}
+	}
+	return nil
+}
+
+// Remove deletes network services and network service endpoints from the server bypassing path update and
+// authorization, so they should come from a trusted source
+func (s *Server) Remove(ctx context.Context, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error {
+	ctx = withInternal(ctx)
+	for _, ns := range nss {
+		if _, err := s.localNSChain.Unregister(ctx, ns.Clone()); err != nil {
+			return errors.Wrapf(err, "failed to remove network service %s", ns.GetName())
+		}
+	}
+	for _, nse := range nses {
+		if _, err := s.localNSEChain.Unregister(ctx, nse.Clone()); err != nil {
+			return errors.Wrapf(err, "failed to remove network service endpoint %s", nse.GetName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registryserver_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+)
+
+func TestServer_ExpireOnLeader(t *testing.T) {
+	const expirePeriod = 100 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var isLeader atomic.Bool
+	registryStorage := storage.NewMemory()
+	registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithStorage(registryStorage),
+		registryserver.WithExpirePeriod(expirePeriod),
+		registryserver.WithLeader(isLeader.Load),
+	)
+	require.NoError(t, registryStorage.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(-time.Second)),
+	}))
+
+	stored := func() bool {
+		nses, err := registryStorage.NetworkServiceEndpoints()
+		require.NoError(t, err)
+		return len(nses) == 1
+	}
+
+	// The followers keep the expired endpoint until the leader unregisters it
+	require.Never(t, func() bool { return !stored() }, 10*expirePeriod, expirePeriod/10)
+
+	isLeader.Store(true)
+	require.Eventually(t, func() bool { return !stored() }, 10*expirePeriod, expirePeriod/10)
+}
+
+// refreshingStorage refreshes the endpoint right after the endpoints are read, e.g. by another registry sharing the
+// storage
+type refreshingStorage struct {
+	storage.Storage
+	refresh atomic.Pointer[registry.NetworkServiceEndpoint]
+}
+
+func (s *refreshingStorage) NetworkServiceEndpoints() ([]*registry.NetworkServiceEndpoint, error) {
+	nses, err := s.Storage.NetworkServiceEndpoints()
+	if nse := s.refresh.Swap(nil); err == nil && nse != nil {
+		err = s.Storage.StoreNetworkServiceEndpoint(nse)
+	}
+	return nses, err
+}
+
+func TestServer_ExpireRefreshed(t *testing.T) {
+	const expirePeriod = 100 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registryStorage := &refreshingStorage{Storage: storage.NewMemory()}
+	require.NoError(t, registryStorage.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(-time.Second)),
+	}))
+	registryStorage.refresh.Store(&registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithStorage(registryStorage),
+		registryserver.WithExpirePeriod(expirePeriod),
+		registryserver.WithLeader(func() bool { return true }),
+	)
+
+	// The endpoint refreshed between the sweep finding it expired and unregistering it is kept
+	require.Eventually(t, func() bool { return registryStorage.refresh.Load() == nil }, 10*expirePeriod, expirePeriod/10)
+	require.Never(t, func() bool {
+		nses, err := registryStorage.Storage.NetworkServiceEndpoints()
+		require.NoError(t, err)
+		return len(nses) == 0
+	}, 10*expirePeriod, expirePeriod/10)
+}
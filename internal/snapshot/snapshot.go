@@ -0,0 +1,185 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot provides persistence of the registry entries to a file, so they survive registry restarts
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Registry is a registry which entries can be dumped and restored
+type Registry interface {
+	// Dump returns all network services and network service endpoints stored by the registry
+	Dump(ctx context.Context) ([]*registry.NetworkService, []*registry.NetworkServiceEndpoint, error)
+	// Restore stores network services and network service endpoints in the registry
+	Restore(ctx context.Context, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error
+}
+
+type file struct {
+	NetworkServices         []json.RawMessage `json:"networkServices"`
+	NetworkServiceEndpoints []json.RawMessage `json:"networkServiceEndpoints"`
+}
+
+// Save atomically writes network services and network service endpoints to the file
+func Save(path string, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error {
+	f := &file{
+		NetworkServices:         make([]json.RawMessage, 0, len(nss)),
+		NetworkServiceEndpoints: make([]json.RawMessage, 0, len(nses)),
+	}
+	for _, ns := range nss {
+		data, err := protojson.Marshal(ns)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal network service %s", ns.GetName())
+		}
+		f.NetworkServices = append(f.NetworkServices, data)
+	}
+	for _, nse := range nses {
+		data, err := protojson.Marshal(nse)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal network service endpoint %s", nse.GetName())
+		}
+		f.NetworkServiceEndpoints = append(f.NetworkServiceEndpoints, data)
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal snapshot")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file for %s", path)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "failed to write %s", tmp.Name())
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "failed to sync %s", tmp.Name())
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", tmp.Name())
+	}
+	return errors.Wrapf(os.Rename(tmp.Name(), path), "failed to rename %s to %s", tmp.Name(), path)
+}
+
+// Load reads network services and network service endpoints from the file. Missing file is treated as empty.
+func Load(path string) ([]*registry.NetworkService, []*registry.NetworkServiceEndpoint, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	f := new(file)
+	if err = json.Unmarshal(data, f); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unmarshal %s", path)
+	}
+	nss := make([]*registry.NetworkService, 0, len(f.NetworkServices))
+	for _, data := range f.NetworkServices {
+		ns := new(registry.NetworkService)
+		if err = protojson.Unmarshal(data, ns); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to unmarshal network service from %s", path)
+		}
+		nss = append(nss, ns)
+	}
+	nses := make([]*registry.NetworkServiceEndpoint, 0, len(f.NetworkServiceEndpoints))
+	for _, data := range f.NetworkServiceEndpoints {
+		nse := new(registry.NetworkServiceEndpoint)
+		if err = protojson.Unmarshal(data, nse); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to unmarshal network service endpoint from %s", path)
+		}
+		nses = append(nses, nse)
+	}
+	return nss, nses, nil
+}
+
+// Restore loads the snapshot from the file and stores its entries in the registry
+func Restore(ctx context.Context, path string, r Registry) error {
+	nss, nses, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err = Apply(ctx, r, nss, nses); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Infof("restored registry from %s", path)
+	return nil
+}
+
+// Apply stores network services and network service endpoints in the registry. Network service endpoints expired
+// while the registry was down are dropped.
+func Apply(ctx context.Context, r Registry, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error {
+	now := time.Now()
+	var alive []*registry.NetworkServiceEndpoint
+	for _, nse := range nses {
+		if nse.GetExpirationTime() != nil && !nse.GetExpirationTime().AsTime().After(now) {
+			log.FromContext(ctx).Infof("dropping expired network service endpoint %s", nse.GetName())
+			continue
+		}
+		alive = append(alive, nse)
+	}
+
+	if err := r.Restore(ctx, nss, alive); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Infof("restored %d network services and %d network service endpoints", len(nss), len(alive))
+	return nil
+}
+
+// Run periodically saves the registry entries to the file until the context is done
+func Run(ctx context.Context, path string, interval time.Duration, r Registry) {
+	logger := log.FromContext(ctx).WithField("snapshot", "Run")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := Flush(ctx, path, r); err != nil {
+				logger.Errorf("failed to save snapshot: %s", err.Error())
+			}
+		}
+	}
+}
+
+// Flush saves the current registry entries to the file
+func Flush(ctx context.Context, path string, r Registry) error {
+	nss, nses, err := r.Dump(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to dump registry")
+	}
+	return Save(path, nss, nses)
+}
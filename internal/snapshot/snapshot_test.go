@@ -0,0 +1,95 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/snapshot"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+
+	nss, nses, err := snapshot.Load(path)
+	require.NoError(t, err)
+	require.Empty(t, nss)
+	require.Empty(t, nses)
+
+	expirationTime := timestamppb.New(time.Now().Add(time.Minute))
+	require.NoError(t, snapshot.Save(path,
+		[]*registry.NetworkService{{Name: "ns-1", Payload: "IP"}},
+		[]*registry.NetworkServiceEndpoint{{
+			Name:                "nse-1",
+			Url:                 "tcp://127.0.0.1",
+			NetworkServiceNames: []string{"ns-1"},
+			ExpirationTime:      expirationTime,
+		}},
+	))
+
+	nss, nses, err = snapshot.Load(path)
+	require.NoError(t, err)
+	require.Len(t, nss, 1)
+	require.Equal(t, "ns-1", nss[0].GetName())
+	require.Equal(t, "IP", nss[0].GetPayload())
+	require.Len(t, nses, 1)
+	require.Equal(t, "nse-1", nses[0].GetName())
+	require.True(t, expirationTime.AsTime().Equal(nses[0].GetExpirationTime().AsTime()))
+}
+
+func TestRestore_DropsExpired(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, snapshot.Save(path,
+		[]*registry.NetworkService{{Name: "ns-1"}},
+		[]*registry.NetworkServiceEndpoint{
+			{
+				Name:                "nse-alive",
+				NetworkServiceNames: []string{"ns-1"},
+				ExpirationTime:      timestamppb.New(time.Now().Add(time.Minute)),
+			},
+			{
+				Name:                "nse-expired",
+				NetworkServiceNames: []string{"ns-1"},
+				ExpirationTime:      timestamppb.New(time.Now().Add(-time.Second)),
+			},
+		},
+	))
+
+	server := registryserver.NewServer(ctx, sandbox.GenerateTestToken)
+	require.NoError(t, snapshot.Restore(ctx, path, server))
+
+	nss, nses, err := server.Dump(ctx)
+	require.NoError(t, err)
+	require.Len(t, nss, 1)
+	require.Equal(t, "ns-1", nss[0].GetName())
+	require.Len(t, nses, 1)
+	require.Equal(t, "nse-alive", nses[0].GetName())
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const defaultRetryInterval = time.Second
+
+// Option modifies the standby option value
+type Option func(s *Standby)
+
+// WithRetryInterval sets the interval between attempts to reconnect to the primary registry
+func WithRetryInterval(d time.Duration) Option {
+	return func(s *Standby) {
+		s.retryInterval = d
+	}
+}
+
+// WithDialOptions sets grpc.DialOptions used to connect to the primary registry
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(s *Standby) {
+		s.dialOptions = dialOptions
+	}
+}
+
+// WithAuthorize sets the check of the clients promoting the standby. All clients are denied by default.
+func WithAuthorize(authorize func(ctx context.Context) error) Option {
+	return func(s *Standby) {
+		s.authorize = authorize
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+)
+
+// isPromoted returns true if the standby is promoted
+func (s *Standby) isPromoted() bool {
+	select {
+	case <-s.promoteCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// notPromotedError returns the error rejecting the write to the standby, the entries are written by the primary
+func notPromotedError(s *Standby) error {
+	return status.Errorf(codes.Unavailable, "registry is a standby of %s until promoted", s.primary)
+}
+
+type standbyNSServer struct {
+	standby *Standby
+}
+
+// NewNetworkServiceRegistryServer creates a new NetworkServiceRegistryServer chain element rejecting registrations
+// and unregistrations of network services until the standby is promoted
+func NewNetworkServiceRegistryServer(s *Standby) registry.NetworkServiceRegistryServer {
+	return &standbyNSServer{
+		standby: s,
+	}
+}
+
+func (s *standbyNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	if !s.standby.isPromoted() {
+		return nil, notPromotedError(s.standby)
+	}
+	return next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+}
+
+func (s *standbyNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *standbyNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	if !s.standby.isPromoted() {
+		return nil, notPromotedError(s.standby)
+	}
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
+
+type standbyNSEServer struct {
+	standby *Standby
+}
+
+// NewNetworkServiceEndpointRegistryServer creates a new NetworkServiceEndpointRegistryServer chain element
+// rejecting registrations and unregistrations of network service endpoints until the standby is promoted
+func NewNetworkServiceEndpointRegistryServer(s *Standby) registry.NetworkServiceEndpointRegistryServer {
+	return &standbyNSEServer{
+		standby: s,
+	}
+}
+
+func (s *standbyNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if !s.standby.isPromoted() {
+		return nil, notPromotedError(s.standby)
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+}
+
+func (s *standbyNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *standbyNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	if !s.standby.isPromoted() {
+		return nil, notPromotedError(s.standby)
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
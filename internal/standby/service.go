@@ -0,0 +1,50 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/jsonrpc"
+)
+
+const (
+	serviceName   = "registrymemory.Standby"
+	promoteMethod = "/" + serviceName + "/Promote"
+)
+
+type standbyServer struct {
+	standby *Standby
+}
+
+func (s *standbyServer) Promote(ctx context.Context, _ *wrapperspb.BytesValue) (*wrapperspb.BytesValue, error) {
+	if err := s.standby.authorize(ctx); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	s.standby.Promote()
+	return new(wrapperspb.BytesValue), nil
+}
+
+// Promote promotes the standby registry reachable through the connection
+func Promote(ctx context.Context, cc grpc.ClientConnInterface) error {
+	return jsonrpc.Invoke(ctx, cc, promoteMethod, nil, nil)
+}
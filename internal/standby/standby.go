@@ -0,0 +1,250 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package standby provides a warm-standby registry mirroring the entries of the primary registry until it is
+// promoted to replace the primary
+package standby
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/jsonrpc"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/snapshot"
+)
+
+// Registry is a registry mirroring the primary registry
+type Registry interface {
+	snapshot.Registry
+	// Remove deletes network services and network service endpoints from the registry
+	Remove(ctx context.Context, nss []*registry.NetworkService, nses []*registry.NetworkServiceEndpoint) error
+	// SetServing sets the status reported by the health service of the registry
+	SetServing(serving bool)
+}
+
+// Standby mirrors the primary registry until it is promoted
+type Standby struct {
+	primary       *url.URL
+	retryInterval time.Duration
+	dialOptions   []grpc.DialOption
+	authorize     func(ctx context.Context) error
+
+	promoteOnce sync.Once
+	promoteCh   chan struct{}
+}
+
+// New creates a standby of the primary registry
+func New(primary *url.URL, options ...Option) *Standby {
+	s := &Standby{
+		primary:       primary,
+		retryInterval: defaultRetryInterval,
+		authorize: func(context.Context) error {
+			return errors.New("clients are not authorized")
+		},
+		promoteCh: make(chan struct{}),
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+// Register registers the service promoting the standby
+func (s *Standby) Register(server *grpc.Server) {
+	jsonrpc.Register(server, serviceName, jsonrpc.Method{Name: "Promote", Handler: (&standbyServer{standby: s}).Promote})
+}
+
+// Promote stops mirroring of the primary registry and makes the registry serving
+func (s *Standby) Promote() {
+	s.promoteOnce.Do(func() {
+		close(s.promoteCh)
+	})
+}
+
+// Promoted returns a channel closed when the standby is promoted
+func (s *Standby) Promoted() <-chan struct{} {
+	return s.promoteCh
+}
+
+// Start reports the registry as not serving and starts mirroring of the primary registry into it until the standby
+// is promoted or the context is done. The promoted registry is reported as serving.
+func (s *Standby) Start(ctx context.Context, r Registry) {
+	r.SetServing(false)
+	go s.run(ctx, r)
+}
+
+func (s *Standby) run(ctx context.Context, r Registry) {
+	logger := log.FromContext(ctx).WithField("standby", "run")
+
+	mirrorCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-mirrorCtx.Done():
+		case <-s.promoteCh:
+			cancel()
+		}
+	}()
+
+	for mirrorCtx.Err() == nil {
+		if err := s.mirror(mirrorCtx, r); err != nil && mirrorCtx.Err() == nil {
+			logger.Warnf("failed to mirror %s: %s", s.primary, err.Error())
+		}
+		select {
+		case <-mirrorCtx.Done():
+		case <-time.After(s.retryInterval):
+		}
+	}
+
+	select {
+	case <-s.promoteCh:
+		logger.Infof("promoted, stopped mirroring %s", s.primary)
+		r.SetServing(true)
+	default:
+	}
+}
+
+func (s *Standby) mirror(ctx context.Context, r Registry) error {
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, grpcutils.URLToTarget(s.primary), s.dialOptions...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to dial %s", s.primary)
+	}
+	defer func() { _ = cc.Close() }()
+
+	nsClient := registry.NewNetworkServiceRegistryClient(cc)
+	nseClient := registry.NewNetworkServiceEndpointRegistryClient(cc)
+
+	if err = s.removeStale(ctx, r, nsClient, nseClient); err != nil {
+		return err
+	}
+
+	mirrorCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	nsStream, err := nsClient.Find(mirrorCtx, &registry.NetworkServiceQuery{
+		NetworkService: new(registry.NetworkService),
+		Watch:          true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch network services")
+	}
+	nseStream, err := nseClient.Find(mirrorCtx, &registry.NetworkServiceEndpointQuery{
+		NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint),
+		Watch:                  true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch network service endpoints")
+	}
+
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- s.mirrorNSs(mirrorCtx, r, nsStream)
+	}()
+	go func() {
+		errCh <- s.mirrorNSEs(mirrorCtx, r, nseStream)
+	}()
+	// Reconnect as soon as any of the streams is broken
+	err = <-errCh
+	cancel()
+	<-errCh
+	return err
+}
+
+// removeStale removes the entries removed from the primary registry while the standby was disconnected
+func (s *Standby) removeStale(ctx context.Context, r Registry, nsClient registry.NetworkServiceRegistryClient,
+	nseClient registry.NetworkServiceEndpointRegistryClient) error {
+	nsStream, err := nsClient.Find(ctx, &registry.NetworkServiceQuery{NetworkService: new(registry.NetworkService)})
+	if err != nil {
+		return errors.Wrap(err, "failed to find network services")
+	}
+	nseStream, err := nseClient.Find(ctx, &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint)})
+	if err != nil {
+		return errors.Wrap(err, "failed to find network service endpoints")
+	}
+	primaryNSs := make(map[string]bool)
+	for _, ns := range registry.ReadNetworkServiceList(nsStream) {
+		primaryNSs[ns.GetName()] = true
+	}
+	primaryNSEs := make(map[string]bool)
+	for _, nse := range registry.ReadNetworkServiceEndpointList(nseStream) {
+		primaryNSEs[nse.GetName()] = true
+	}
+
+	nss, nses, err := r.Dump(ctx)
+	if err != nil {
+		return err
+	}
+	var staleNSs []*registry.NetworkService
+	for _, ns := range nss {
+		if !primaryNSs[ns.GetName()] {
+			staleNSs = append(staleNSs, ns)
+		}
+	}
+	var staleNSEs []*registry.NetworkServiceEndpoint
+	for _, nse := range nses {
+		if !primaryNSEs[nse.GetName()] {
+			staleNSEs = append(staleNSEs, nse)
+		}
+	}
+	return r.Remove(ctx, staleNSs, staleNSEs)
+}
+
+func (s *Standby) mirrorNSs(ctx context.Context, r Registry, stream registry.NetworkServiceRegistry_FindClient) error {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return errors.Wrap(err, "network services watch is broken")
+		}
+		if resp.GetDeleted() {
+			err = r.Remove(ctx, []*registry.NetworkService{resp.GetNetworkService()}, nil)
+		} else {
+			err = r.Restore(ctx, []*registry.NetworkService{resp.GetNetworkService()}, nil)
+		}
+		if err != nil {
+			log.FromContext(ctx).WithField("standby", "mirrorNSs").Warnf("failed to mirror %s: %s",
+				resp.GetNetworkService().GetName(), err.Error())
+		}
+	}
+}
+
+func (s *Standby) mirrorNSEs(ctx context.Context, r Registry, stream registry.NetworkServiceEndpointRegistry_FindClient) error {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return errors.Wrap(err, "network service endpoints watch is broken")
+		}
+		if resp.GetDeleted() {
+			err = r.Remove(ctx, nil, []*registry.NetworkServiceEndpoint{resp.GetNetworkServiceEndpoint()})
+		} else {
+			err = r.Restore(ctx, nil, []*registry.NetworkServiceEndpoint{resp.GetNetworkServiceEndpoint()})
+		}
+		if err != nil {
+			log.FromContext(ctx).WithField("standby", "mirrorNSEs").Warnf("failed to mirror %s: %s",
+				resp.GetNetworkServiceEndpoint().GetName(), err.Error())
+		}
+	}
+}
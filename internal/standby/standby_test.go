@@ -0,0 +1,163 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standby_test
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/standby"
+)
+
+func serve(ctx context.Context, t *testing.T, register func(*grpc.Server)) *grpc.ClientConn {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	register(server)
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(server.Stop)
+
+	// nolint:staticcheck
+	cc, err := grpc.DialContext(ctx, ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cc.Close() })
+	return cc
+}
+
+func healthStatus(ctx context.Context, t *testing.T, cc *grpc.ClientConn) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	resp, err := grpc_health_v1.NewHealthClient(cc).Check(ctx, &grpc_health_v1.HealthCheckRequest{
+		Service: "registry.NetworkServiceEndpointRegistry",
+	})
+	require.NoError(t, err)
+	return resp.GetStatus()
+}
+
+func TestStandby(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	primary := registryserver.NewServer(ctx, sandbox.GenerateTestToken)
+	primaryCC := serve(ctx, t, primary.Register)
+	nsClient := next.NewNetworkServiceRegistryClient(
+		grpcmetadata.NewNetworkServiceRegistryClient(),
+		registry.NewNetworkServiceRegistryClient(primaryCC),
+	)
+	nseClient := next.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(primaryCC),
+	)
+
+	// The entries registered before the standby starts are mirrored as well
+	_, err := nsClient.Register(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+
+	primaryURL := &url.URL{Scheme: "tcp", Host: primaryCC.Target()}
+	s := standby.New(primaryURL,
+		standby.WithRetryInterval(10*time.Millisecond),
+		standby.WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		standby.WithAuthorize(func(context.Context) error { return nil }),
+	)
+	mirror := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithNSFrontServers(standby.NewNetworkServiceRegistryServer(s)),
+		registryserver.WithNSEFrontServers(standby.NewNetworkServiceEndpointRegistryServer(s)),
+	)
+	mirrorCC := serve(ctx, t, func(server *grpc.Server) {
+		mirror.Register(server)
+		s.Register(server)
+	})
+	mirrorNSEClient := next.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(mirrorCC),
+	)
+
+	s.Start(ctx, mirror)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, healthStatus(ctx, t, mirrorCC))
+
+	require.Eventually(t, func() bool {
+		nss, _, dumpErr := mirror.Dump(ctx)
+		require.NoError(t, dumpErr)
+		return len(nss) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Registrations and unregistrations are mirrored
+	_, err = nseClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-1",
+		NetworkServiceNames: []string{"ns-1"},
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, nses, dumpErr := mirror.Dump(ctx)
+		require.NoError(t, dumpErr)
+		return len(nses) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// The clients can't write to the standby until it is promoted
+	_, err = mirrorNSEClient.Register(ctx, &registry.NetworkServiceEndpoint{
+		Name:                "nse-2",
+		NetworkServiceNames: []string{"ns-1"},
+	})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = mirrorNSEClient.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = nsClient.Unregister(ctx, &registry.NetworkService{Name: "ns-1"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		nss, _, dumpErr := mirror.Dump(ctx)
+		require.NoError(t, dumpErr)
+		return len(nss) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// The promoted standby serves the mirrored entries and doesn't follow the primary anymore
+	require.NoError(t, standby.Promote(ctx, mirrorCC))
+	require.Eventually(t, func() bool {
+		return healthStatus(ctx, t, mirrorCC) == grpc_health_v1.HealthCheckResponse_SERVING
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = nsClient.Register(ctx, &registry.NetworkService{Name: "ns-2"})
+	require.NoError(t, err)
+	require.Never(t, func() bool {
+		nss, _, dumpErr := mirror.Dump(ctx)
+		require.NoError(t, dumpErr)
+		return len(nss) != 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	_, nses, err := mirror.Dump(ctx)
+	require.NoError(t, err)
+	require.Len(t, nses, 1)
+
+	_, err = mirrorNSEClient.Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-1"})
+	require.NoError(t, err)
+}
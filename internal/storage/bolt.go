@@ -0,0 +1,160 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+var (
+	networkServicesBucket         = []byte("networkServices")
+	networkServiceEndpointsBucket = []byte("networkServiceEndpoints")
+)
+
+type boltStorage struct {
+	db *bolt.DB
+}
+
+// NewBolt creates a storage keeping entries in the bbolt database file
+func NewBolt(path string) (Storage, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{networkServicesBucket, networkServiceEndpointsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return errors.Wrapf(err, "failed to create bucket %s", bucket)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &boltStorage{
+		db: db,
+	}, nil
+}
+
+func (s *boltStorage) NetworkServices() (nss []*registry.NetworkService, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(networkServicesBucket).ForEach(func(k, v []byte) error {
+			ns := new(registry.NetworkService)
+			if err := proto.Unmarshal(v, ns); err != nil {
+				return errors.Wrapf(err, "failed to unmarshal network service %s", k)
+			}
+			nss = append(nss, ns)
+			return nil
+		})
+	})
+	return nss, err
+}
+
+func (s *boltStorage) StoreNetworkService(ns *registry.NetworkService) error {
+	return s.store(networkServicesBucket, ns.GetName(), ns)
+}
+
+func (s *boltStorage) DeleteNetworkService(name string) (*registry.NetworkService, error) {
+	ns := new(registry.NetworkService)
+	if ok, err := s.delete(networkServicesBucket, name, ns); !ok || err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+func (s *boltStorage) NetworkServiceEndpoints() (nses []*registry.NetworkServiceEndpoint, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(networkServiceEndpointsBucket).ForEach(func(k, v []byte) error {
+			nse := new(registry.NetworkServiceEndpoint)
+			if err := proto.Unmarshal(v, nse); err != nil {
+				return errors.Wrapf(err, "failed to unmarshal network service endpoint %s", k)
+			}
+			nses = append(nses, nse)
+			return nil
+		})
+	})
+	return nses, err
+}
+
+func (s *boltStorage) StoreNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint) error {
+	return s.store(networkServiceEndpointsBucket, nse.GetName(), nse)
+}
+
+func (s *boltStorage) DeleteNetworkServiceEndpoint(name string) (*registry.NetworkServiceEndpoint, error) {
+	nse := new(registry.NetworkServiceEndpoint)
+	if ok, err := s.delete(networkServiceEndpointsBucket, name, nse); !ok || err != nil {
+		return nil, err
+	}
+	return nse, nil
+}
+
+func (s *boltStorage) ExpireNetworkServiceEndpoint(expired *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	nse := new(registry.NetworkServiceEndpoint)
+	ok, err := s.deleteIf(networkServiceEndpointsBucket, expired.GetName(), nse, func() bool {
+		return proto.Equal(nse.GetExpirationTime(), expired.GetExpirationTime())
+	})
+	if !ok || err != nil {
+		return nil, err
+	}
+	return nse, nil
+}
+
+func (s *boltStorage) Close() error {
+	return errors.Wrap(s.db.Close(), "failed to close bolt database")
+}
+
+func (s *boltStorage) store(bucket []byte, name string, m proto.Message) error {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", name)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return errors.Wrapf(tx.Bucket(bucket).Put([]byte(name), data), "failed to store %s", name)
+	})
+}
+
+func (s *boltStorage) delete(bucket []byte, name string, m proto.Message) (ok bool, err error) {
+	return s.deleteIf(bucket, name, m, func() bool { return true })
+}
+
+// deleteIf deletes the entry unmarshalled to m if the condition checking m is met
+func (s *boltStorage) deleteIf(bucket []byte, name string, m proto.Message, condition func() bool) (ok bool, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+		data := b.Get([]byte(name))
+		if data == nil {
+			return nil
+		}
+		if err := proto.Unmarshal(data, m); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal %s", name)
+		}
+		if !condition() {
+			return nil
+		}
+		ok = true
+		return errors.Wrapf(b.Delete([]byte(name)), "failed to delete %s", name)
+	})
+	return ok, err
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+type memoryStorage struct {
+	mu                      sync.RWMutex
+	networkServices         map[string]*registry.NetworkService
+	networkServiceEndpoints map[string]*registry.NetworkServiceEndpoint
+}
+
+// NewMemory creates a storage keeping entries in memory
+func NewMemory() Storage {
+	return &memoryStorage{
+		networkServices:         make(map[string]*registry.NetworkService),
+		networkServiceEndpoints: make(map[string]*registry.NetworkServiceEndpoint),
+	}
+}
+
+func (s *memoryStorage) NetworkServices() ([]*registry.NetworkService, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	nss := make([]*registry.NetworkService, 0, len(s.networkServices))
+	for _, ns := range s.networkServices {
+		nss = append(nss, ns.Clone())
+	}
+	return nss, nil
+}
+
+func (s *memoryStorage) StoreNetworkService(ns *registry.NetworkService) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.networkServices[ns.GetName()] = ns.Clone()
+	return nil
+}
+
+func (s *memoryStorage) DeleteNetworkService(name string) (*registry.NetworkService, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ns, ok := s.networkServices[name]
+	if !ok {
+		return nil, nil
+	}
+	delete(s.networkServices, name)
+	return ns, nil
+}
+
+func (s *memoryStorage) NetworkServiceEndpoints() ([]*registry.NetworkServiceEndpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	nses := make([]*registry.NetworkServiceEndpoint, 0, len(s.networkServiceEndpoints))
+	for _, nse := range s.networkServiceEndpoints {
+		nses = append(nses, nse.Clone())
+	}
+	return nses, nil
+}
+
+func (s *memoryStorage) StoreNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.networkServiceEndpoints[nse.GetName()] = nse.Clone()
+	return nil
+}
+
+func (s *memoryStorage) DeleteNetworkServiceEndpoint(name string) (*registry.NetworkServiceEndpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nse, ok := s.networkServiceEndpoints[name]
+	if !ok {
+		return nil, nil
+	}
+	delete(s.networkServiceEndpoints, name)
+	return nse, nil
+}
+
+func (s *memoryStorage) ExpireNetworkServiceEndpoint(expired *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nse, ok := s.networkServiceEndpoints[expired.GetName()]
+	if !ok || !proto.Equal(nse.GetExpirationTime(), expired.GetExpirationTime()) {
+		return nil, nil
+	}
+	delete(s.networkServiceEndpoints, expired.GetName())
+	return nse, nil
+}
+
+func (s *memoryStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/edwarnicke/serialize"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+)
+
+type storageNSServer struct {
+	storage       Storage
+	executor      serialize.Executor
+	eventChannels map[string]chan *registry.NetworkServiceResponse
+}
+
+// NewNetworkServiceRegistryServer creates new NetworkServiceRegistryServer keeping network services in the storage
+func NewNetworkServiceRegistryServer(storage Storage) registry.NetworkServiceRegistryServer {
+	s := &storageNSServer{
+		storage:       storage,
+		eventChannels: make(map[string]chan *registry.NetworkServiceResponse),
+	}
+	if notifier, ok := storage.(Notifier); ok {
+		notifier.OnNetworkServiceChange(func(ns *registry.NetworkService, deleted bool) {
+			s.sendEvent(&registry.NetworkServiceResponse{NetworkService: ns, Deleted: deleted})
+		})
+	}
+	return s
+}
+
+func (s *storageNSServer) Register(ctx context.Context, ns *registry.NetworkService) (*registry.NetworkService, error) {
+	r, err := next.NetworkServiceRegistryServer(ctx).Register(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.storage.StoreNetworkService(r); err != nil {
+		return nil, err
+	}
+
+	s.sendEvent(&registry.NetworkServiceResponse{NetworkService: r})
+
+	return r, nil
+}
+
+func (s *storageNSServer) sendEvent(event *registry.NetworkServiceResponse) {
+	event = event.Clone()
+	s.executor.AsyncExec(func() {
+		for _, ch := range s.eventChannels {
+			ch <- event.Clone()
+		}
+	})
+}
+
+func (s *storageNSServer) Find(query *registry.NetworkServiceQuery, server registry.NetworkServiceRegistry_FindServer) error {
+	if !query.Watch {
+		matches, err := s.allMatches(query)
+		if err != nil {
+			return err
+		}
+		for _, ns := range matches {
+			nsResp := &registry.NetworkServiceResponse{
+				NetworkService: ns,
+			}
+			if err := server.Send(nsResp); err != nil {
+				return errors.Wrapf(err, "NetworkServiceRegistry find server failed to send a response %s", nsResp.String())
+			}
+		}
+		return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+	}
+
+	eventCh := make(chan *registry.NetworkServiceResponse, defaultEventChannelSize)
+	id := uuid.New().String()
+
+	errCh := make(chan error, 1)
+	s.executor.AsyncExec(func() {
+		matches, err := s.allMatches(query)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		s.eventChannels[id] = eventCh
+		for _, entity := range matches {
+			eventCh <- &registry.NetworkServiceResponse{NetworkService: entity}
+		}
+	})
+	defer s.closeEventChannel(id, eventCh)
+
+	var err error
+	for ; err == nil; err = s.receiveEvent(query, server, eventCh, errCh) {
+	}
+	if !errors.Is(err, io.EOF) {
+		return err
+	}
+	return next.NetworkServiceRegistryServer(server.Context()).Find(query, server)
+}
+
+func (s *storageNSServer) allMatches(query *registry.NetworkServiceQuery) (matches []*registry.NetworkService, err error) {
+	nss, err := s.storage.NetworkServices()
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range nss {
+		if matchutils.MatchNetworkServices(query.GetNetworkService(), ns) {
+			matches = append(matches, ns)
+		}
+	}
+	return matches, nil
+}
+
+func (s *storageNSServer) closeEventChannel(id string, eventCh <-chan *registry.NetworkServiceResponse) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.executor.AsyncExec(func() {
+		delete(s.eventChannels, id)
+		cancel()
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-eventCh:
+		}
+	}
+}
+
+func (s *storageNSServer) receiveEvent(
+	query *registry.NetworkServiceQuery,
+	server registry.NetworkServiceRegistry_FindServer,
+	eventCh <-chan *registry.NetworkServiceResponse,
+	errCh <-chan error,
+) error {
+	select {
+	case <-server.Context().Done():
+		return errors.WithStack(io.EOF)
+	case err := <-errCh:
+		return err
+	case event := <-eventCh:
+		if matchutils.MatchNetworkServices(query.GetNetworkService(), event.GetNetworkService()) {
+			if err := server.Send(event); err != nil {
+				if server.Context().Err() != nil {
+					return errors.WithStack(io.EOF)
+				}
+				return errors.Wrapf(err, "NetworkServiceRegistry find server failed to send a response %s", event.String())
+			}
+		}
+		return nil
+	}
+}
+
+func (s *storageNSServer) Unregister(ctx context.Context, ns *registry.NetworkService) (*empty.Empty, error) {
+	unregisterNS, err := s.storage.DeleteNetworkService(ns.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if unregisterNS != nil {
+		s.sendEvent(&registry.NetworkServiceResponse{NetworkService: unregisterNS, Deleted: true})
+	}
+	return next.NetworkServiceRegistryServer(ctx).Unregister(ctx, ns)
+}
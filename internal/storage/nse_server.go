@@ -0,0 +1,203 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/edwarnicke/serialize"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/expiry"
+)
+
+type storageNSEServer struct {
+	storage       Storage
+	executor      serialize.Executor
+	eventChannels map[string]chan *registry.NetworkServiceEndpointResponse
+}
+
+// NewNetworkServiceEndpointRegistryServer creates new NetworkServiceEndpointRegistryServer keeping network service
+// endpoints in the storage
+func NewNetworkServiceEndpointRegistryServer(storage Storage) registry.NetworkServiceEndpointRegistryServer {
+	s := &storageNSEServer{
+		storage:       storage,
+		eventChannels: make(map[string]chan *registry.NetworkServiceEndpointResponse),
+	}
+	if notifier, ok := storage.(Notifier); ok {
+		notifier.OnNetworkServiceEndpointChange(func(nse *registry.NetworkServiceEndpoint, deleted bool) {
+			s.sendEvent(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: nse, Deleted: deleted})
+		})
+	}
+	return s
+}
+
+func (s *storageNSEServer) Register(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	r, err := next.NetworkServiceEndpointRegistryServer(ctx).Register(ctx, nse)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.storage.StoreNetworkServiceEndpoint(r); err != nil {
+		return nil, err
+	}
+
+	s.sendEvent(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: r})
+
+	return r, nil
+}
+
+func (s *storageNSEServer) sendEvent(event *registry.NetworkServiceEndpointResponse) {
+	event = event.Clone()
+	s.executor.AsyncExec(func() {
+		for _, ch := range s.eventChannels {
+			ch <- event.Clone()
+		}
+	})
+}
+
+func (s *storageNSEServer) Find(query *registry.NetworkServiceEndpointQuery, server registry.NetworkServiceEndpointRegistry_FindServer) error {
+	if !query.Watch {
+		matches, err := s.allMatches(query)
+		if err != nil {
+			return err
+		}
+		for _, nse := range matches {
+			nseResp := &registry.NetworkServiceEndpointResponse{
+				NetworkServiceEndpoint: nse,
+			}
+			if err := server.Send(nseResp); err != nil {
+				return errors.Wrapf(err, "NetworkServiceEndpointRegistry find server failed to send a response %s", nseResp.String())
+			}
+		}
+		return next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server)
+	}
+
+	if err := next.NetworkServiceEndpointRegistryServer(server.Context()).Find(query, server); err != nil {
+		return err
+	}
+
+	eventCh := make(chan *registry.NetworkServiceEndpointResponse, defaultEventChannelSize)
+	id := uuid.New().String()
+
+	errCh := make(chan error, 1)
+	s.executor.AsyncExec(func() {
+		matches, err := s.allMatches(query)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		s.eventChannels[id] = eventCh
+		for _, entity := range matches {
+			eventCh <- &registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: entity}
+		}
+	})
+	defer s.closeEventChannel(id, eventCh)
+
+	var err error
+	for ; err == nil; err = s.receiveEvent(query, server, eventCh, errCh) {
+	}
+	if !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+func (s *storageNSEServer) allMatches(query *registry.NetworkServiceEndpointQuery) (matches []*registry.NetworkServiceEndpoint, err error) {
+	nses, err := s.storage.NetworkServiceEndpoints()
+	if err != nil {
+		return nil, err
+	}
+	for _, nse := range nses {
+		if matchutils.MatchNetworkServiceEndpoints(query.GetNetworkServiceEndpoint(), nse) {
+			matches = append(matches, nse)
+		}
+	}
+	return matches, nil
+}
+
+func (s *storageNSEServer) closeEventChannel(id string, eventCh <-chan *registry.NetworkServiceEndpointResponse) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.executor.AsyncExec(func() {
+		delete(s.eventChannels, id)
+		cancel()
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-eventCh:
+		}
+	}
+}
+
+func (s *storageNSEServer) receiveEvent(
+	query *registry.NetworkServiceEndpointQuery,
+	server registry.NetworkServiceEndpointRegistry_FindServer,
+	eventCh <-chan *registry.NetworkServiceEndpointResponse,
+	errCh <-chan error,
+) error {
+	select {
+	case <-server.Context().Done():
+		return errors.WithStack(io.EOF)
+	case err := <-errCh:
+		return err
+	case event := <-eventCh:
+		if matchutils.MatchNetworkServiceEndpoints(query.GetNetworkServiceEndpoint(), event.GetNetworkServiceEndpoint()) {
+			if err := server.Send(event); err != nil {
+				if server.Context().Err() != nil {
+					return errors.WithStack(io.EOF)
+				}
+				return errors.Wrapf(err, "NetworkServiceEndpointRegistry find server failed to send a response %s", event.String())
+			}
+		}
+		return nil
+	}
+}
+
+func (s *storageNSEServer) Unregister(ctx context.Context, nse *registry.NetworkServiceEndpoint) (*empty.Empty, error) {
+	var unregisterNSE *registry.NetworkServiceEndpoint
+	var err error
+	if expiry.IsExpired(ctx) {
+		// The endpoint refreshed since it has expired, e.g. through another registry sharing the storage, is kept
+		expired := nse.Clone()
+		expired.ExpirationTime = expiry.ExpirationTime(ctx)
+		unregisterNSE, err = s.storage.ExpireNetworkServiceEndpoint(expired)
+		if err == nil && unregisterNSE == nil {
+			err = errors.Wrapf(ErrNotExpired, "failed to expire %s", nse.GetName())
+		}
+	} else {
+		unregisterNSE, err = s.storage.DeleteNetworkServiceEndpoint(nse.GetName())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if unregisterNSE != nil {
+		s.sendEvent(&registry.NetworkServiceEndpointResponse{NetworkServiceEndpoint: unregisterNSE, Deleted: true})
+	}
+	return next.NetworkServiceEndpointRegistryServer(ctx).Unregister(ctx, nse)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storage provides storages of the registry entries and registry chain elements keeping the entries in them
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/snapshot"
+)
+
+const (
+	// MemoryBackend is a name of the storage backend keeping entries in memory
+	MemoryBackend = "memory"
+	// BoltBackend is a name of the storage backend keeping entries in a bbolt database file
+	BoltBackend = "bolt"
+)
+
+// ErrNotExpired is returned by the registry chain elements on the expiration of the network service endpoint which is
+// refreshed or deleted since it has expired
+var ErrNotExpired = errors.New("network service endpoint is not expired")
+
+// Storage stores network services and network service endpoints by their names
+type Storage interface {
+	// NetworkServices returns all stored network services
+	NetworkServices() ([]*registry.NetworkService, error)
+	// StoreNetworkService stores the network service replacing the one with the same name
+	StoreNetworkService(ns *registry.NetworkService) error
+	// DeleteNetworkService deletes the network service by name and returns it if it was stored
+	DeleteNetworkService(name string) (*registry.NetworkService, error)
+	// NetworkServiceEndpoints returns all stored network service endpoints
+	NetworkServiceEndpoints() ([]*registry.NetworkServiceEndpoint, error)
+	// StoreNetworkServiceEndpoint stores the network service endpoint replacing the one with the same name
+	StoreNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint) error
+	// DeleteNetworkServiceEndpoint deletes the network service endpoint by name and returns it if it was stored
+	DeleteNetworkServiceEndpoint(name string) (*registry.NetworkServiceEndpoint, error)
+	// ExpireNetworkServiceEndpoint deletes the network service endpoint if it is stored with the expiration time of
+	// the expired one and returns it if it was deleted, so the endpoint refreshed since it has expired is kept
+	ExpireNetworkServiceEndpoint(nse *registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error)
+	// Close releases resources held by the storage
+	Close() error
+}
+
+// Notifier is implemented by the storages which entries can be changed bypassing the registry chain, e.g. by
+// replication from other registries. Registry chain elements subscribe to it to send events to the watching clients.
+type Notifier interface {
+	// OnNetworkServiceChange subscribes to changes of network services made bypassing the registry chain
+	OnNetworkServiceChange(f func(ns *registry.NetworkService, deleted bool))
+	// OnNetworkServiceEndpointChange subscribes to changes of network service endpoints made bypassing the registry
+	// chain
+	OnNetworkServiceEndpointChange(f func(nse *registry.NetworkServiceEndpoint, deleted bool))
+}
+
+// New creates a storage for the backend. Path is used by the backends persisting entries to a file.
+func New(backend, path string) (Storage, error) {
+	switch backend {
+	case MemoryBackend:
+		return NewMemory(), nil
+	case BoltBackend:
+		if path == "" {
+			return nil, errors.Errorf("storage path is required for %s backend", backend)
+		}
+		return NewBolt(path)
+	default:
+		return nil, errors.Errorf("unknown storage backend: %q", backend)
+	}
+}
+
+// Restore registers the entries kept by the storage in the registry, so expiration of the network service endpoints
+// is tracked again. Network service endpoints expired while the registry was down are deleted from the storage.
+func Restore(ctx context.Context, s Storage, r snapshot.Registry) error {
+	nss, err := s.NetworkServices()
+	if err != nil {
+		return err
+	}
+	nses, err := s.NetworkServiceEndpoints()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, nse := range nses {
+		if nse.GetExpirationTime() != nil && !nse.GetExpirationTime().AsTime().After(now) {
+			if _, err = s.DeleteNetworkServiceEndpoint(nse.GetName()); err != nil {
+				return err
+			}
+		}
+	}
+	return snapshot.Apply(ctx, r, nss, nses)
+}
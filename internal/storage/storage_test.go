@@ -0,0 +1,164 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+)
+
+func TestStorage(t *testing.T) {
+	for backend, create := range map[string]func(t *testing.T) storage.Storage{
+		storage.MemoryBackend: func(*testing.T) storage.Storage {
+			return storage.NewMemory()
+		},
+		storage.BoltBackend: func(t *testing.T) storage.Storage {
+			s, err := storage.NewBolt(filepath.Join(t.TempDir(), "registry.db"))
+			require.NoError(t, err)
+			return s
+		},
+	} {
+		t.Run(backend, func(t *testing.T) {
+			s := create(t)
+			defer func() { require.NoError(t, s.Close()) }()
+
+			require.NoError(t, s.StoreNetworkService(&registry.NetworkService{Name: "ns-1"}))
+			require.NoError(t, s.StoreNetworkService(&registry.NetworkService{Name: "ns-1", Payload: "ETHERNET"}))
+			nss, err := s.NetworkServices()
+			require.NoError(t, err)
+			require.Len(t, nss, 1)
+			require.Equal(t, "ETHERNET", nss[0].GetPayload())
+
+			require.NoError(t, s.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{Name: "nse-1"}))
+			require.NoError(t, s.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{Name: "nse-2"}))
+			nses, err := s.NetworkServiceEndpoints()
+			require.NoError(t, err)
+			require.Len(t, nses, 2)
+
+			nse, err := s.DeleteNetworkServiceEndpoint("nse-1")
+			require.NoError(t, err)
+			require.Equal(t, "nse-1", nse.GetName())
+			nse, err = s.DeleteNetworkServiceEndpoint("nse-1")
+			require.NoError(t, err)
+			require.Nil(t, nse)
+
+			// The endpoint refreshed since it has expired is kept
+			expirationTime := timestamppb.New(time.Now())
+			require.NoError(t, s.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{
+				Name:           "nse-2",
+				ExpirationTime: timestamppb.New(expirationTime.AsTime().Add(time.Minute)),
+			}))
+			nse, err = s.ExpireNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{Name: "nse-2", ExpirationTime: expirationTime})
+			require.NoError(t, err)
+			require.Nil(t, nse)
+			require.NoError(t, s.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{Name: "nse-2", ExpirationTime: expirationTime}))
+			nse, err = s.ExpireNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{Name: "nse-2", ExpirationTime: expirationTime})
+			require.NoError(t, err)
+			require.Equal(t, "nse-2", nse.GetName())
+			nses, err = s.NetworkServiceEndpoints()
+			require.NoError(t, err)
+			require.Empty(t, nses)
+
+			ns, err := s.DeleteNetworkService("ns-1")
+			require.NoError(t, err)
+			require.Equal(t, "ns-1", ns.GetName())
+			nss, err = s.NetworkServices()
+			require.NoError(t, err)
+			require.Empty(t, nss)
+		})
+	}
+}
+
+func TestNew_UnknownBackend(t *testing.T) {
+	_, err := storage.New("etcd", "")
+	require.Error(t, err)
+	_, err = storage.New(storage.BoltBackend, "")
+	require.Error(t, err)
+}
+
+func TestBolt_SurvivesRestart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "registry.db")
+
+	s, err := storage.NewBolt(path)
+	require.NoError(t, err)
+	server := registryserver.NewServer(ctx, sandbox.GenerateTestToken, registryserver.WithStorage(s))
+	require.NoError(t, server.Restore(ctx,
+		[]*registry.NetworkService{{Name: "ns-1"}},
+		[]*registry.NetworkServiceEndpoint{
+			{Name: "nse-1", NetworkServiceNames: []string{"ns-1"}, ExpirationTime: timestamppb.New(time.Now().Add(time.Minute))},
+		},
+	))
+	require.NoError(t, s.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{
+		Name:           "nse-expired",
+		ExpirationTime: timestamppb.New(time.Now().Add(-time.Second)),
+	}))
+	require.NoError(t, s.Close())
+
+	s, err = storage.NewBolt(path)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, s.Close()) }()
+	server = registryserver.NewServer(ctx, sandbox.GenerateTestToken, registryserver.WithStorage(s))
+	require.NoError(t, storage.Restore(ctx, s, server))
+
+	stream, err := adapters.NetworkServiceEndpointServerToClient(server.NetworkServiceEndpointRegistryServer()).Find(ctx,
+		&registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{NetworkServiceNames: []string{"ns-1"}}})
+	require.NoError(t, err)
+	nses := registry.ReadNetworkServiceEndpointList(stream)
+	require.Len(t, nses, 1)
+	require.Equal(t, "nse-1", nses[0].GetName())
+
+	nses, err = s.NetworkServiceEndpoints()
+	require.NoError(t, err)
+	require.Len(t, nses, 1)
+}
+
+func TestNetworkServiceEndpointRegistryServer_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := registryserver.NewServer(ctx, sandbox.GenerateTestToken)
+	require.NoError(t, server.Restore(ctx, nil, []*registry.NetworkServiceEndpoint{{Name: "nse-1"}}))
+
+	stream, err := adapters.NetworkServiceEndpointServerToClient(server.NetworkServiceEndpointRegistryServer()).Find(ctx,
+		&registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: new(registry.NetworkServiceEndpoint), Watch: true})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "nse-1", resp.GetNetworkServiceEndpoint().GetName())
+
+	require.NoError(t, server.Restore(ctx, nil, []*registry.NetworkServiceEndpoint{{Name: "nse-2"}}))
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "nse-2", resp.GetNetworkServiceEndpoint().GetName())
+}
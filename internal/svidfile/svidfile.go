@@ -0,0 +1,158 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package svidfile provides the X.509 SVID and trust bundle source loading them from the PEM files, it replaces the
+// SPIRE agent where there is none.
+package svidfile
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Source is the source of the X.509 SVID and the trust bundle of its trust domain loaded from the files. The source
+// keeps the last successfully loaded SVID and bundle if the files are changed to invalid ones.
+type Source struct {
+	certPath   string
+	keyPath    string
+	bundlePath string
+
+	mu     sync.RWMutex
+	err    error
+	svid   *x509svid.SVID
+	bundle *x509bundle.Bundle
+}
+
+// New loads the SVID from the certificate chain and the private key files and the trust bundle from the file of the
+// trust domain CA certificates
+func New(certPath, keyPath, bundlePath string) (*Source, error) {
+	s := &Source{
+		certPath:   certPath,
+		keyPath:    keyPath,
+		bundlePath: bundlePath,
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload loads the files again and swaps the SVID and the bundle if they are valid
+func (s *Source) Reload() error {
+	svid, bundle, err := load(s.certPath, s.keyPath, s.bundlePath)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+	if err != nil {
+		return err
+	}
+	s.svid, s.bundle = svid, bundle
+	return nil
+}
+
+// Err returns the error of the last reload, the current SVID and bundle are stale if it is not nil
+func (s *Source) Err() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.err
+}
+
+// GetX509SVID returns the current SVID
+func (s *Source) GetX509SVID() (*x509svid.SVID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.svid, nil
+}
+
+// GetX509BundleForTrustDomain returns the current bundle if it is of the trust domain
+func (s *Source) GetX509BundleForTrustDomain(td spiffeid.TrustDomain) (*x509bundle.Bundle, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.bundle.GetX509BundleForTrustDomain(td)
+}
+
+// Run watches the directories of the files and reloads them on changes until the context is done. The directories
+// are watched, so the files replaced by renames and the Kubernetes secret volumes updated by symlinks are reloaded.
+func (s *Source) Run(ctx context.Context) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to watch SVID files: %s", err.Error())
+		return
+	}
+	defer func() { _ = watcher.Close() }()
+
+	watched := make(map[string]struct{})
+	for _, path := range []string{s.certPath, s.keyPath, s.bundlePath} {
+		dir := filepath.Dir(path)
+		if _, ok := watched[dir]; ok {
+			continue
+		}
+		watched[dir] = struct{}{}
+		if err := watcher.Add(dir); err != nil {
+			log.FromContext(ctx).Errorf("failed to watch SVID files %s: %s", dir, err.Error())
+		}
+	}
+
+	// The files changed between the load and the watch are reloaded
+	if err := s.Reload(); err != nil {
+		log.FromContext(ctx).Errorf("failed to reload SVID files, keeping the current ones: %s", err.Error())
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-watcher.Errors:
+			log.FromContext(ctx).Warnf("SVID files watch error: %s", err.Error())
+		case <-watcher.Events:
+			if err := s.Reload(); err != nil {
+				log.FromContext(ctx).Errorf("failed to reload SVID files, keeping the current ones: %s", err.Error())
+				continue
+			}
+			svid, _ := s.GetX509SVID()
+			log.FromContext(ctx).Infof("SVID %s is reloaded, it expires at %s", svid.ID, svid.Certificates[0].NotAfter)
+		}
+	}
+}
+
+func load(certPath, keyPath, bundlePath string) (*x509svid.SVID, *x509bundle.Bundle, error) {
+	svid, err := x509svid.Load(certPath, keyPath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load SVID from %s and %s", certPath, keyPath)
+	}
+	bundle, err := x509bundle.Load(svid.ID.TrustDomain(), bundlePath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to load trust bundle from %s", bundlePath)
+	}
+	if _, _, err := x509svid.Verify(svid.Certificates, bundle); err != nil {
+		return nil, nil, errors.Wrapf(err, "SVID %s is not verified by the trust bundle", svid.ID)
+	}
+	return svid, bundle, nil
+}
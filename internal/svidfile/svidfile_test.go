@@ -0,0 +1,149 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package svidfile_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/svidfile"
+)
+
+type ca struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newCA(t *testing.T) *ca {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		URIs:                  []*url.URL{spiffeid.RequireTrustDomainFromString("example.org").ID().URL()},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &ca{cert: cert, key: key}
+}
+
+// writeSVID writes the certificate and the key of the SVID of the SPIFFE ID issued by the CA
+func (c *ca) writeSVID(t *testing.T, dir, id string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	u, err := url.Parse(id)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{u},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, c.cert, &key.PublicKey, c.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cert.pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bundle.pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0o600))
+}
+
+func TestSource(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	certPath, keyPath, bundlePath := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), filepath.Join(dir, "bundle.pem")
+	exampleCA := newCA(t)
+	exampleCA.writeSVID(t, dir, "spiffe://example.org/registry")
+
+	source, err := svidfile.New(certPath, keyPath, bundlePath)
+	require.NoError(t, err)
+	go source.Run(ctx)
+
+	svid, err := source.GetX509SVID()
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/registry", svid.ID.String())
+	bundle, err := source.GetX509BundleForTrustDomain(svid.ID.TrustDomain())
+	require.NoError(t, err)
+	require.True(t, bundle.HasX509Authority(exampleCA.cert))
+	_, err = source.GetX509BundleForTrustDomain(spiffeid.RequireTrustDomainFromString("other.org"))
+	require.Error(t, err)
+
+	// The tokens are signed by the key of the SVID
+	tok, _, err := spiffejwt.TokenGeneratorFunc(source, time.Minute)(nil)
+	require.NoError(t, err)
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tok, &claims, func(*jwt.Token) (interface{}, error) {
+		return svid.Certificates[0].PublicKey, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/registry", claims["sub"])
+
+	// The changed files are reloaded
+	exampleCA.writeSVID(t, dir, "spiffe://example.org/registry-2")
+	require.Eventually(t, func() bool {
+		svid, _ = source.GetX509SVID()
+		return svid.ID.String() == "spiffe://example.org/registry-2" && source.Err() == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// The invalid files are not loaded, the current SVID is kept
+	require.NoError(t, os.WriteFile(certPath, []byte("garbage"), 0o600))
+	require.Eventually(t, func() bool {
+		return source.Err() != nil
+	}, 5*time.Second, 50*time.Millisecond)
+	svid, err = source.GetX509SVID()
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/registry-2", svid.ID.String())
+}
+
+func TestNew_Untrusted(t *testing.T) {
+	dir := t.TempDir()
+	newCA(t).writeSVID(t, dir, "spiffe://example.org/registry")
+
+	// The bundle of another CA does not verify the SVID
+	other := t.TempDir()
+	newCA(t).writeSVID(t, other, "spiffe://example.org/registry")
+
+	_, err := svidfile.New(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), filepath.Join(other, "bundle.pem"))
+	require.ErrorContains(t, err, "is not verified by the trust bundle")
+	_, err = svidfile.New(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), filepath.Join(dir, "missing.pem"))
+	require.Error(t, err)
+}
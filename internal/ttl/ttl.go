@@ -0,0 +1,131 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ttl provides the expiration policies of the network service endpoints selected by their network services
+package ttl
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+)
+
+// Duration is a time.Duration encoded as a string, e.g. "1m30s"
+type Duration time.Duration
+
+// MarshalJSON encodes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes the duration from a string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrap(err, "duration should be a string")
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return errors.Wrapf(err, "invalid duration %q", s)
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// Policy sets the expiration of the endpoints registered for the matching network services
+type Policy struct {
+	// NetworkService is the pattern of the network service names, see path.Match, any network service matches if
+	// it is empty
+	NetworkService string `json:"networkService,omitempty"`
+	// Labels should be set on the endpoint for the matching network service
+	Labels map[string]string `json:"labels,omitempty"`
+	// DefaultExpiration is the expiration of the endpoints registered without expiration time, the registry default
+	// is used if it is 0
+	DefaultExpiration Duration `json:"defaultExpiration,omitempty"`
+	// MaxExpiration is the maximum expiration of the endpoints, the registry maximum is used if it is 0
+	MaxExpiration Duration `json:"maxExpiration,omitempty"`
+}
+
+// Matches returns true if one of the network services of the endpoint matches the policy
+func (p *Policy) Matches(nse *registry.NetworkServiceEndpoint) bool {
+	for _, ns := range nse.GetNetworkServiceNames() {
+		if matched, _ := path.Match(p.NetworkService, ns); p.NetworkService != "" && !matched {
+			continue
+		}
+		if hasLabels(nse.GetNetworkServiceLabels()[ns].GetLabels(), p.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) validate() error {
+	if _, err := path.Match(p.NetworkService, ""); err != nil {
+		return errors.Wrapf(err, "invalid network service pattern %q", p.NetworkService)
+	}
+	if p.DefaultExpiration < 0 || p.MaxExpiration < 0 {
+		return errors.New("expiration should not be negative")
+	}
+	if p.MaxExpiration > 0 && p.DefaultExpiration > p.MaxExpiration {
+		return errors.Errorf("default expiration %v exceeds max expiration %v",
+			time.Duration(p.DefaultExpiration), time.Duration(p.MaxExpiration))
+	}
+	return nil
+}
+
+func hasLabels(labels, expected map[string]string) bool {
+	for k, v := range expected {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Select returns the first policy matching the endpoint or nil if there is no one
+func Select(policies []*Policy, nse *registry.NetworkServiceEndpoint) *Policy {
+	for _, p := range policies {
+		if p.Matches(nse) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Load reads the list of the policies from the YAML or JSON file
+func Load(policiesPath string) ([]*Policy, error) {
+	data, err := os.ReadFile(filepath.Clean(policiesPath))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read TTL policies %s", policiesPath)
+	}
+	var policies []*Policy
+	if err := yaml.UnmarshalStrict(data, &policies); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse TTL policies %s", policiesPath)
+	}
+	for i, p := range policies {
+		if err := p.validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid TTL policy %d of %s", i, policiesPath)
+		}
+	}
+	return policies, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/ttl"
+)
+
+const policiesYAML = `
+- networkService: test-*
+  defaultExpiration: 5s
+  maxExpiration: 10s
+- networkService: gateway
+  labels:
+    role: edge
+  defaultExpiration: 2m
+  maxExpiration: 2h
+`
+
+func writePolicies(t *testing.T, data string) string {
+	policiesPath := filepath.Join(t.TempDir(), "ttl.yaml")
+	require.NoError(t, os.WriteFile(policiesPath, []byte(data), 0o600))
+	return policiesPath
+}
+
+func TestLoad(t *testing.T) {
+	policies, err := ttl.Load(writePolicies(t, policiesYAML))
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	require.Equal(t, ttl.Duration(5*time.Second), policies[0].DefaultExpiration)
+	require.Equal(t, map[string]string{"role": "edge"}, policies[1].Labels)
+
+	for name, data := range map[string]string{
+		"unknown field":       "- networkService: test-*\n  ttl: 5s\n",
+		"invalid pattern":     "- networkService: test-[\n",
+		"invalid duration":    "- defaultExpiration: 5\n",
+		"default exceeds max": "- defaultExpiration: 10s\n  maxExpiration: 5s\n",
+	} {
+		_, err = ttl.Load(writePolicies(t, data))
+		require.Error(t, err, name)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	policies, err := ttl.Load(writePolicies(t, policiesYAML))
+	require.NoError(t, err)
+
+	require.Equal(t, policies[0], ttl.Select(policies, &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"ns-1", "test-1"},
+	}))
+	require.Equal(t, policies[1], ttl.Select(policies, &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"gateway"},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"gateway": {Labels: map[string]string{"role": "edge", "zone": "a"}},
+		},
+	}))
+	require.Nil(t, ttl.Select(policies, &registry.NetworkServiceEndpoint{
+		NetworkServiceNames: []string{"gateway"},
+		NetworkServiceLabels: map[string]*registry.NetworkServiceLabels{
+			"gateway": {Labels: map[string]string{"role": "core"}},
+		},
+	}))
+}
+
+func TestRegistryServer_TTLPolicies(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	policies, err := ttl.Load(writePolicies(t, policiesYAML))
+	require.NoError(t, err)
+	registryServer := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithDefaultExpiration(time.Minute),
+		registryserver.WithMaxExpiration(time.Hour),
+		registryserver.WithTTLPolicies(policies...),
+	)
+
+	gatewayLabels := map[string]*registry.NetworkServiceLabels{"gateway": {Labels: map[string]string{"role": "edge"}}}
+	now := time.Now()
+	require.NoError(t, registryServer.Restore(ctx, nil, []*registry.NetworkServiceEndpoint{
+		{Name: "test-default", NetworkServiceNames: []string{"test-1"}},
+		{Name: "test-max", NetworkServiceNames: []string{"test-1"}, ExpirationTime: timestamppb.New(now.Add(time.Hour))},
+		{Name: "gateway-default", NetworkServiceNames: []string{"gateway"}, NetworkServiceLabels: gatewayLabels},
+		{Name: "gateway-max", NetworkServiceNames: []string{"gateway"}, NetworkServiceLabels: gatewayLabels,
+			ExpirationTime: timestamppb.New(now.Add(3 * time.Hour))},
+		{Name: "other-default", NetworkServiceNames: []string{"other"}},
+	}))
+
+	_, nses, err := registryServer.Dump(ctx)
+	require.NoError(t, err)
+	expected := map[string]time.Duration{
+		"test-default":    5 * time.Second,
+		"test-max":        10 * time.Second,
+		"gateway-default": 2 * time.Minute,
+		// The policy can't extend the registry max expiration
+		"gateway-max":   time.Hour,
+		"other-default": time.Minute,
+	}
+	require.Len(t, nses, len(expected))
+	for _, nse := range nses {
+		require.WithinDuration(t, now.Add(expected[nse.GetName()]), nse.GetExpirationTime().AsTime(), time.Second, nse.GetName())
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+const defaultCompactionThreshold = 1000
+
+// Option is an option pattern for New
+type Option func(l *Log)
+
+// WithCompactionThreshold sets the number of records which triggers compaction of the log into the snapshot.
+// Zero disables compaction.
+func WithCompactionThreshold(threshold int) Option {
+	return func(l *Log) {
+		l.compactionThreshold = threshold
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wal provides an append-only write-ahead log of the registry mutations. The log is periodically compacted
+// into a snapshot and replayed on startup, so no registration is lost when the registry crashes.
+package wal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/snapshot"
+)
+
+const (
+	opRegister   = "register"
+	opUnregister = "unregister"
+	opExpire     = "expire"
+
+	kindNS  = "ns"
+	kindNSE = "nse"
+
+	maxRecordSize = 16 * 1024 * 1024
+)
+
+type record struct {
+	Op    string          `json:"op"`
+	Kind  string          `json:"kind"`
+	Entry json.RawMessage `json:"entry"`
+}
+
+// Log is a write-ahead log of the registry mutations
+type Log struct {
+	path                string
+	snapshotPath        string
+	compactionThreshold int
+
+	mu        sync.Mutex
+	file      *os.File
+	records   int
+	compactCh chan struct{}
+}
+
+// New creates a write-ahead log stored at path and compacted into the snapshot stored at snapshotPath. The log
+// doesn't record anything until it is restored.
+func New(path, snapshotPath string, options ...Option) *Log {
+	l := &Log{
+		path:                path,
+		snapshotPath:        snapshotPath,
+		compactionThreshold: defaultCompactionThreshold,
+		compactCh:           make(chan struct{}, 1),
+	}
+	for _, opt := range options {
+		opt(l)
+	}
+	return l
+}
+
+// Restore replays the snapshot and the log into the registry, compacts them and starts recording of the registry
+// mutations
+func (l *Log) Restore(ctx context.Context, r snapshot.Registry) error {
+	nss, nses, err := l.replay(ctx)
+	if err != nil {
+		return err
+	}
+	if err = snapshot.Apply(ctx, r, nss, nses); err != nil {
+		return err
+	}
+	return l.Compact(ctx, r)
+}
+
+// Run compacts the log into the snapshot each time it grows over the compaction threshold until the context is done
+func (l *Log) Run(ctx context.Context, r snapshot.Registry) {
+	for {
+		select {
+		case <-ctx.Done():
+			l.mu.Lock()
+			if l.file != nil {
+				_ = l.file.Close()
+				l.file = nil
+			}
+			l.mu.Unlock()
+			return
+		case <-l.compactCh:
+			if err := l.Compact(ctx, r); err != nil {
+				log.FromContext(ctx).WithField("wal", "Run").Errorf("failed to compact: %s", err.Error())
+			}
+		}
+	}
+}
+
+func (l *Log) replay(ctx context.Context) ([]*registry.NetworkService, []*registry.NetworkServiceEndpoint, error) {
+	nss, nses, err := snapshot.Load(l.snapshotPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	nsMap := make(map[string]*registry.NetworkService)
+	for _, ns := range nss {
+		nsMap[ns.GetName()] = ns
+	}
+	nseMap := make(map[string]*registry.NetworkServiceEndpoint)
+	for _, nse := range nses {
+		nseMap[nse.GetName()] = nse
+	}
+
+	f, err := os.Open(filepath.Clean(l.path))
+	if os.IsNotExist(err) {
+		return nss, nses, nil
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to open %s", l.path)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxRecordSize)
+	for count := 1; scanner.Scan(); count++ {
+		r := new(record)
+		if err = json.Unmarshal(scanner.Bytes(), r); err == nil {
+			err = r.apply(nsMap, nseMap)
+		}
+		if err != nil {
+			// The last record may be partially written if the registry crashed
+			log.FromContext(ctx).Warnf("skipping the rest of %s from record %d: %s", l.path, count, err.Error())
+			break
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read %s", l.path)
+	}
+
+	nss = make([]*registry.NetworkService, 0, len(nsMap))
+	for _, ns := range nsMap {
+		nss = append(nss, ns)
+	}
+	nses = make([]*registry.NetworkServiceEndpoint, 0, len(nseMap))
+	for _, nse := range nseMap {
+		nses = append(nses, nse)
+	}
+	return nss, nses, nil
+}
+
+func (r *record) apply(nsMap map[string]*registry.NetworkService, nseMap map[string]*registry.NetworkServiceEndpoint) error {
+	switch r.Kind {
+	case kindNS:
+		ns := new(registry.NetworkService)
+		if err := protojson.Unmarshal(r.Entry, ns); err != nil {
+			return errors.Wrap(err, "failed to unmarshal network service")
+		}
+		if r.Op == opRegister {
+			nsMap[ns.GetName()] = ns
+		} else {
+			delete(nsMap, ns.GetName())
+		}
+	case kindNSE:
+		nse := new(registry.NetworkServiceEndpoint)
+		if err := protojson.Unmarshal(r.Entry, nse); err != nil {
+			return errors.Wrap(err, "failed to unmarshal network service endpoint")
+		}
+		if r.Op == opRegister {
+			nseMap[nse.GetName()] = nse
+		} else {
+			delete(nseMap, nse.GetName())
+		}
+	default:
+		return errors.Errorf("unknown record kind: %q", r.Kind)
+	}
+	return nil
+}
+
+// Compact saves the registry entries to the snapshot and truncates the log
+func (l *Log) Compact(ctx context.Context, r snapshot.Registry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	nss, nses, err := r.Dump(ctx)
+	if err != nil {
+		return err
+	}
+	if err = snapshot.Save(l.snapshotPath, nss, nses); err != nil {
+		return err
+	}
+
+	if l.file != nil {
+		_ = l.file.Close()
+		l.file = nil
+	}
+	f, err := os.OpenFile(filepath.Clean(l.path), os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", l.path)
+	}
+	l.file = f
+	l.records = 0
+
+	log.FromContext(ctx).Debugf("compacted %s into %s", l.path, l.snapshotPath)
+	return nil
+}
+
+func (l *Log) append(ctx context.Context, op, kind string, entry proto.Message) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return
+	}
+
+	err := l.write(op, kind, entry)
+	if err != nil {
+		log.FromContext(ctx).WithField("wal", "append").Errorf("failed to record %s: %s", op, err.Error())
+		return
+	}
+
+	l.records++
+	if l.compactionThreshold > 0 && l.records >= l.compactionThreshold {
+		select {
+		case l.compactCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (l *Log) write(op, kind string, entry proto.Message) error {
+	data, err := protojson.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal entry")
+	}
+	line, err := json.Marshal(&record{
+		Op:    op,
+		Kind:  kind,
+		Entry: data,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal record")
+	}
+	if _, err = l.file.Write(append(line, '\n')); err != nil {
+		return errors.Wrapf(err, "failed to write %s", l.path)
+	}
+	return errors.Wrapf(l.file.Sync(), "failed to sync %s", l.path)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/networkservicemesh/api/pkg/api/registry"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/wal"
+)
+
+func newServer(ctx context.Context, t *testing.T, dir string) *registryserver.Server {
+	l := wal.New(filepath.Join(dir, "wal.log"), filepath.Join(dir, "snapshot.json"))
+	server := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithNSRegistryServers(wal.NewNetworkServiceRegistryServer(l)),
+		registryserver.WithNSERegistryServers(wal.NewNetworkServiceEndpointRegistryServer(l)),
+	)
+	require.NoError(t, l.Restore(ctx, server))
+	go l.Run(ctx, server)
+	return server
+}
+
+func TestLog_RecoversAfterCrash(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	server := newServer(ctx, t, dir)
+
+	expirationTime := timestamppb.New(time.Now().Add(time.Minute))
+	require.NoError(t, server.Restore(ctx,
+		[]*registry.NetworkService{{Name: "ns-1"}},
+		[]*registry.NetworkServiceEndpoint{
+			{Name: "nse-1", NetworkServiceNames: []string{"ns-1"}, ExpirationTime: expirationTime},
+			{Name: "nse-2", NetworkServiceNames: []string{"ns-1"}, ExpirationTime: expirationTime},
+		},
+	))
+	_, err := server.NetworkServiceEndpointRegistryServer().Unregister(ctx, &registry.NetworkServiceEndpoint{Name: "nse-2"})
+	require.NoError(t, err)
+
+	// Simulate a crash in the middle of writing a record
+	f, err := os.OpenFile(filepath.Join(dir, "wal.log"), os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"op":"register","kind":"nse","entry":{"na`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	restored := newServer(ctx, t, dir)
+	nss, nses, err := restored.Dump(ctx)
+	require.NoError(t, err)
+	require.Len(t, nss, 1)
+	require.Equal(t, "ns-1", nss[0].GetName())
+	require.Len(t, nses, 1)
+	require.Equal(t, "nse-1", nses[0].GetName())
+}
+
+func TestLog_Compaction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	l := wal.New(filepath.Join(dir, "wal.log"), filepath.Join(dir, "snapshot.json"), wal.WithCompactionThreshold(2))
+	server := registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+		registryserver.WithNSRegistryServers(wal.NewNetworkServiceRegistryServer(l)),
+	)
+	require.NoError(t, l.Restore(ctx, server))
+	go l.Run(ctx, server)
+
+	require.NoError(t, server.Restore(ctx, []*registry.NetworkService{{Name: "ns-1"}, {Name: "ns-2"}}, nil))
+
+	require.Eventually(t, func() bool {
+		info, err := os.Stat(filepath.Join(dir, "wal.log"))
+		return err == nil && info.Size() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	restored := newServer(ctx, t, dir)
+	nss, _, err := restored.Dump(ctx)
+	require.NoError(t, err)
+	require.Len(t, nss, 2)
+}
+
+func TestLog_Expire(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	server := newServer(ctx, t, dir)
+
+	// The expire chain element unregisters the endpoints earlier by the timeout of their registration requests
+	requestCtx, requestCancel := context.WithTimeout(ctx, time.Second)
+	defer requestCancel()
+	nseServer := server.NetworkServiceEndpointRegistryServer()
+	_, err := nseServer.Register(requestCtx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-1",
+		ExpirationTime: timestamppb.New(time.Now().Add(1500 * time.Millisecond)),
+	})
+	require.NoError(t, err)
+	nse, err := nseServer.Register(requestCtx, &registry.NetworkServiceEndpoint{
+		Name:           "nse-2",
+		ExpirationTime: timestamppb.New(time.Now().Add(time.Minute)),
+	})
+	require.NoError(t, err)
+	_, err = nseServer.Unregister(requestCtx, nse)
+	require.NoError(t, err)
+
+	ops := func() map[string]string {
+		data, err := os.ReadFile(filepath.Join(dir, "wal.log"))
+		require.NoError(t, err)
+		result := make(map[string]string)
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var r struct {
+				Op    string `json:"op"`
+				Entry struct {
+					Name string `json:"name"`
+				} `json:"entry"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(line), &r))
+			result[r.Entry.Name] = r.Op
+		}
+		return result
+	}
+	require.Eventually(t, func() bool {
+		return ops()["nse-1"] == "expire"
+	}, 5*time.Second, 20*time.Millisecond)
+	require.Equal(t, "unregister", ops()["nse-2"])
+}
@@ -2,7 +2,7 @@
 //
 // Copyright (c) 2023 Cisco Systems, Inc.
 //
-// Copyright (c) 2024 OpenInfra Foundation Europe. All rights reserved.
+// Copyright (c) 2024-2026 OpenInfra Foundation Europe. All rights reserved.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -26,6 +26,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"flag"
 	"net/url"
 	"os"
 	"os/signal"
@@ -41,47 +42,120 @@ import (
 
 	nested "github.com/antonfisher/nested-logrus-formatter"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
 	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"github.com/spiffe/go-spiffe/v2/workloadapi"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
-	"github.com/networkservicemesh/sdk/pkg/registry/chains/memory"
-	"github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
 	"github.com/networkservicemesh/sdk/pkg/tools/debug"
 	"github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
-	"github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/admin"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/antientropy"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/audit"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/catalog"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/cluster"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/debughttp"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/drain"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/election"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/healthcheck"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/metrics"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/peerauth"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/policyreload"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/quota"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/ratelimit"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/snapshot"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/standby"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/svidfile"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/ttl"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/wal"
 )
 
 // Config is configuration for cmd-registry-memory
 type Config struct {
 	ListenOn               []url.URL     `default:"unix:///listen.on.socket" desc:"url to listen on." split_words:"true"`
 	MaxTokenLifetime       time.Duration `default:"10m" desc:"maximum lifetime of tokens" split_words:"true"`
+	SVIDSource             string        `default:"spire" desc:"source of the X.509 SVID and the trust bundle, spire for the SPIRE agent or files" envconfig:"SVID_SOURCE"`
+	SVIDCertPath           string        `desc:"path to the PEM file of the SVID certificate chain of the files SVID source" envconfig:"SVID_CERT_PATH"`
+	SVIDKeyPath            string        `desc:"path to the PEM file of the SVID private key of the files SVID source" envconfig:"SVID_KEY_PATH"`
+	TrustBundlePath        string        `desc:"path to the PEM file of the trust domain CA certificates of the files SVID source" split_words:"true"`
+	DrainTimeout           time.Duration `default:"10s" desc:"deadline for in-flight requests to complete on shutdown before the server is stopped" split_words:"true"`
 	RegistryServerPolicies []string      `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/server/.*.rego" desc:"paths to files and directories that contain registry server policies" split_words:"true"`
 	RegistryClientPolicies []string      `default:"etc/nsm/opa/common/.*.rego,etc/nsm/opa/registry/.*.rego,etc/nsm/opa/client/.*.rego" desc:"paths to files and directories that contain registry client policies" split_words:"true"`
 	ProxyRegistryURL       url.URL       `desc:"url to the proxy registry that handles this domain" split_words:"true"`
+	AllowedPeers           []string      `desc:"rules of the SPIFFE IDs allowed to connect to the registry and of the registry peers, trust-domain:<trust domain>, prefix:<SPIFFE ID prefix> or regex:<regex>, any peer is allowed if empty" split_words:"true"`
+	ProxyAllowedPeers      []string      `desc:"rules of the SPIFFE IDs allowed for the proxy registry in the form of NSM_ALLOWED_PEERS, any peer is allowed if empty" split_words:"true"`
 	ExpirePeriod           time.Duration `default:"1s" desc:"period to check expired NSEs" split_words:"true"`
+	DefaultExpiration      time.Duration `default:"1m" desc:"expiration of NSEs registered without expiration time" split_words:"true"`
+	MaxExpiration          time.Duration `default:"1h" desc:"maximum expiration of NSEs" split_words:"true"`
+	ExpirationGracePeriod  time.Duration `default:"0" desc:"period expired NSEs are kept stale before the deletion, stale NSEs are hidden from non-watch finds, disabled if 0" split_words:"true"`
+	TombstoneTTL           time.Duration `default:"0" desc:"period tombstones of deleted expired NSEs are kept for late refreshes to resurrect them, disabled if 0" split_words:"true"`
+	TTLPoliciesPath        string        `desc:"path to the YAML or JSON file of the policies overriding the NSE expirations by network service, disabled if empty" split_words:"true"`
 	LogLevel               string        `default:"INFO" desc:"Log level" split_words:"true"`
 	OpenTelemetryEndpoint  string        `default:"otel-collector.observability.svc.cluster.local:4317" desc:"OpenTelemetry Collector Endpoint" split_words:"true"`
 	MetricsExportInterval  time.Duration `default:"10s" desc:"interval between mertics exports" split_words:"true"`
 	PprofEnabled           bool          `default:"false" desc:"is pprof enabled" split_words:"true"`
 	PprofListenOn          string        `default:"localhost:6060" desc:"pprof URL to ListenAndServe" split_words:"true"`
+	SnapshotPath           string        `desc:"path to the file to persist registry entries to, persistence is disabled if empty" split_words:"true"`
+	SnapshotInterval       time.Duration `default:"5s" desc:"interval between snapshots of registry entries" split_words:"true"`
+	WALPath                string        `desc:"path to the write-ahead log of registry mutations, requires snapshot path, disabled if empty" split_words:"true"`
+	WALCompactionThreshold int           `default:"1000" desc:"number of write-ahead log records which triggers its compaction into the snapshot" split_words:"true"`
+	SnapshotOnShutdown     bool          `default:"true" desc:"save the final snapshot or compact the write-ahead log on shutdown" split_words:"true"`
+	StorageBackend         string        `default:"memory" desc:"storage backend of registry entries: memory or bolt" split_words:"true"`
+	StoragePath            string        `desc:"path to the storage file, required by bolt backend" split_words:"true"`
+	ClusterNodeID          string        `desc:"url other cluster members reach this registry at, clustered mode is disabled if empty" split_words:"true"`
+	ClusterMembers         []string      `desc:"cluster members as <node id>=<raft address>, including this registry" split_words:"true"`
+	ClusterDataDir         string        `desc:"directory the raft state of the cluster is kept in over restarts, it is kept in memory if empty" split_words:"true"`
+	Peers                  []url.URL     `desc:"urls of the registry replicas to synchronize entries with, synchronization is disabled if empty" split_words:"true"`
+	PeersSyncInterval      time.Duration `default:"5s" desc:"interval between synchronizations with the peers" split_words:"true"`
+	ElectionLockPath       string        `desc:"path to the lock file shared by the registries to elect the leader serving the clients, election is disabled if empty" split_words:"true"`
+	ElectionAdvertiseURL   url.URL       `desc:"url the other registries redirect clients to when this registry is the leader, the first listen on url by default" split_words:"true"`
+	StandbyPrimaryURL      url.URL       `desc:"url of the primary registry to mirror until promoted by SIGWINCH or Promote RPC, standby mode is disabled if empty" split_words:"true"`
+	AdminSpiffeIDs         []string      `desc:"SPIFFE IDs allowed to use the admin service by the default admin policy" envconfig:"ADMIN_SPIFFE_IDS"`
+	AdminPolicies          []string      `desc:"paths to files and directories that contain admin policies replacing the default one, admin service is disabled if neither admin SPIFFE IDs nor policies are set" split_words:"true"`
+	DebugHTTPEnabled       bool          `default:"false" desc:"is the read-only HTTP/JSON view of the registry contents enabled" split_words:"true"`
+	DebugHTTPListenOn      string        `default:"localhost:6061" desc:"address the HTTP/JSON view of the registry contents listens on" split_words:"true"`
+	CatalogPath            string        `desc:"path to the directory of network service manifests kept in the registry and protected from unregistration, catalog is disabled if empty" split_words:"true"`
+	PrometheusEnabled      bool          `default:"false" desc:"is the Prometheus metrics listener enabled" split_words:"true"`
+	PrometheusListenOn     string        `default:":9090" desc:"address the Prometheus metrics are served on at /metrics" split_words:"true"`
+	HealthCheckInterval    time.Duration `default:"5s" desc:"interval between health checks of the registry components, each check is limited by it" split_words:"true"`
+	HealthHTTPEnabled      bool          `default:"false" desc:"serve /livez and /readyz over HTTP" split_words:"true"`
+	HealthHTTPListenOn     string        `default:":8081" desc:"address the /livez and /readyz endpoints are served on" split_words:"true"`
+	RegisterRateLimit      float64       `default:"0" desc:"registrations per second allowed for each caller SPIFFE ID, disabled if 0" split_words:"true"`
+	RegisterBurst          int           `default:"10" desc:"registrations allowed at once for each caller SPIFFE ID" split_words:"true"`
+	FindRateLimit          float64       `default:"0" desc:"finds per second allowed for each caller SPIFFE ID, disabled if 0" split_words:"true"`
+	FindBurst              int           `default:"20" desc:"finds allowed at once for each caller SPIFFE ID" split_words:"true"`
+	RateLimitByService     bool          `default:"false" desc:"limit the requests of each caller for each network service separately" split_words:"true"`
+	MaxNSEs                int           `default:"0" desc:"maximum number of the stored NSEs, unlimited if 0" envconfig:"MAX_NSES"`
+	MaxNSEsPerID           int           `default:"0" desc:"maximum number of the stored NSEs registered by each SPIFFE ID, unlimited if 0" envconfig:"MAX_NSES_PER_ID"`
+	MaxNSEsPerService      int           `default:"0" desc:"maximum number of the stored NSEs of each network service, unlimited if 0" envconfig:"MAX_NSES_PER_SERVICE"`
+	TrustDomainMaxNSEs     []string      `desc:"maximum numbers of the stored NSEs registered by each SPIFFE ID of the trust domains overriding NSM_MAX_NSES_PER_ID, <trust domain>=<limit>" envconfig:"TRUST_DOMAIN_MAX_NSES"`
+	AuditLogPath           string        `desc:"path to the audit log file of registry mutations and authorization denials, - for stdout, audit log is disabled if empty" split_words:"true"`
+	AuditLogMaxSize        int64         `default:"104857600" desc:"size in bytes of the audit log file which triggers its rotation" split_words:"true"`
+	AuditLogMaxBackups     int           `default:"5" desc:"number of the rotated audit log files to keep" split_words:"true"`
 }
 
 func main() {
-	// Setup context to catch signals
-	ctx, cancel := signal.NotifyContext(
-		context.Background(),
+	// Setup context to catch signals, the registry runs until the shutdown started by them is completed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	shutdownCtx, shutdown := signal.NotifyContext(
+		ctx,
 		os.Interrupt,
-		// More Linux signals here
-		syscall.SIGHUP,
+		// More Linux signals here, SIGHUP reloads the config file and the registry policies
 		syscall.SIGTERM,
 		syscall.SIGQUIT,
 	)
-	defer cancel()
+	defer shutdown()
 
 	// Setup logging
 	log.EnableTracing(true)
@@ -93,30 +167,32 @@ func main() {
 		log.FromContext(ctx).Infof("%s", err)
 	}
 
+	// Get the config file path, the remaining arguments run a command instead of the registry
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	configPath := flags.String("config", os.Getenv(configFileEnv), "path to the YAML config file, "+configFileEnv+" by default")
+	_ = flags.Parse(os.Args[1:])
+
+	if flags.NArg() > 0 {
+		runCommand(ctx, *configPath, flags.Args())
+		return
+	}
+
 	startTime := time.Now()
 
-	// Get config from environment
-	config := &Config{}
-	if err := envconfig.Usage("nsm", config); err != nil {
+	// Get config from the config file and environment
+	if err := envconfig.Usage(envPrefix, &Config{}); err != nil {
 		logrus.Fatal(err)
 	}
-	if err := envconfig.Process("nsm", config); err != nil {
-		logrus.Fatalf("error processing config from env: %+v", err)
-	}
-
-	l, err := logrus.ParseLevel(config.LogLevel)
+	config, err := loadConfig(*configPath)
 	if err != nil {
-		logrus.Fatalf("invalid log level %s", config.LogLevel)
+		logrus.Fatal(err.Error())
 	}
+
+	l, _ := logrus.ParseLevel(config.LogLevel)
 	logrus.SetLevel(l)
 
 	log.FromContext(ctx).Infof("Config: %#v", config)
 
-	logruslogger.SetupLevelChangeOnSignal(ctx, map[os.Signal]logrus.Level{
-		syscall.SIGUSR1: logrus.TraceLevel,
-		syscall.SIGUSR2: l,
-	})
-
 	// Configure Open Telemetry
 	if opentelemetry.IsEnabled() {
 		collectorAddress := config.OpenTelemetryEndpoint
@@ -130,25 +206,33 @@ func main() {
 		}()
 	}
 
-	// Configure pprof
-	if config.PprofEnabled {
-		go pprofutils.ListenAndServe(ctx, config.PprofListenOn)
-	}
+	// Configure pprof, it can be enabled and disabled by the config reload
+	profiler := new(pprofServer)
+	profiler.Set(ctx, config.PprofEnabled, config.PprofListenOn)
 
 	// Get a X509Source
-	source, err := workloadapi.NewX509Source(ctx)
+	source, closeSource, err := newX509Source(ctx, config)
 	if err != nil {
 		logrus.Fatalf("error getting x509 source: %+v", err)
 	}
+	defer closeSource()
 	svid, err := source.GetX509SVID()
 	if err != nil {
 		logrus.Fatalf("error getting x509 svid: %+v", err)
 	}
 	logrus.Infof("SVID: %q", svid.ID)
 
-	tlsClientConfig := tlsconfig.MTLSClientConfig(source, source, tlsconfig.AuthorizeAny())
-	tlsClientConfig.MinVersion = tls.VersionTLS12
-	tlsServerConfig := tlsconfig.MTLSServerConfig(source, source, tlsconfig.AuthorizeAny())
+	// The peers are authorized by the TLS handshake
+	peerAuthorizer, err := peerauth.NewAuthorizer(config.AllowedPeers)
+	if err != nil {
+		logrus.Fatalf("error parsing allowed peers: %+v", err)
+	}
+	proxyAuthorizer, err := peerauth.NewAuthorizer(config.ProxyAllowedPeers)
+	if err != nil {
+		logrus.Fatalf("error parsing proxy allowed peers: %+v", err)
+	}
+
+	tlsServerConfig := tlsconfig.MTLSServerConfig(source, source, peerAuthorizer)
 	tlsServerConfig.MinVersion = tls.VersionTLS12
 
 	credsTLS := credentials.NewTLS(tlsServerConfig)
@@ -156,7 +240,357 @@ func main() {
 	serverOptions := append(tracing.WithTracing(), grpc.Creds(credsTLS))
 	server := grpc.NewServer(serverOptions...)
 
-	clientOptions := append(
+	// The registry peers are authorized as the inbound connections, the proxy registry has its own rules
+	clientOptions := newClientOptions(source, config, peerAuthorizer)
+	proxyClientOptions := newClientOptions(source, config, proxyAuthorizer)
+
+	registryStorage, err := storage.New(config.StorageBackend, config.StoragePath)
+	if err != nil {
+		logrus.Fatalf("error creating storage: %+v", err)
+	}
+	defer func() {
+		if err = registryStorage.Close(); err != nil {
+			log.FromContext(ctx).Error(err.Error())
+		}
+	}()
+
+	var node *cluster.Node
+	if config.ClusterNodeID != "" {
+		node = joinCluster(ctx, config, source, svid.ID, registryStorage, clientOptions)
+		node.Register(server)
+		registryStorage = node
+	}
+
+	policies, err := policyreload.New(config.RegistryServerPolicies, config.RegistryClientPolicies)
+	if err != nil {
+		logrus.Fatalf("error loading registry policies: %+v", err)
+	}
+	go policies.Run(ctx)
+
+	drainer := drain.New()
+	registryOptions := []registryserver.Option{
+		registryserver.WithNSFrontServers(drain.NewNetworkServiceRegistryServer(drainer)),
+		registryserver.WithNSEFrontServers(drain.NewNetworkServiceEndpointRegistryServer(drainer)),
+		registryserver.WithStorage(registryStorage),
+		registryserver.WithAuthorizeNSERegistryServer(policyreload.NewNetworkServiceEndpointRegistryServer(policies)),
+		registryserver.WithAuthorizeNSERegistryClient(policyreload.NewNetworkServiceEndpointRegistryClient(policies)),
+		registryserver.WithAuthorizeNSRegistryServer(policyreload.NewNetworkServiceRegistryServer(policies)),
+		registryserver.WithAuthorizeNSRegistryClient(policyreload.NewNetworkServiceRegistryClient(policies)),
+		registryserver.WithDefaultExpiration(config.DefaultExpiration),
+		registryserver.WithMaxExpiration(config.MaxExpiration),
+		registryserver.WithExpirePeriod(config.ExpirePeriod),
+		registryserver.WithTTLPolicies(loadTTLPolicies(config)...),
+		registryserver.WithGracePeriod(config.ExpirationGracePeriod),
+		registryserver.WithTombstoneTTL(config.TombstoneTTL),
+		registryserver.WithProxyRegistryURL(&config.ProxyRegistryURL),
+		registryserver.WithDialOptions(proxyClientOptions...),
+	}
+	if node != nil {
+		// Only the cluster leader sweeps the expired NSEs, the followers get the unregistrations replicated
+		registryOptions = append(registryOptions, registryserver.WithLeader(node.IsLeader))
+	}
+
+	var registryMetrics *metrics.Metrics
+	if config.PrometheusEnabled {
+		registryMetrics = metrics.New()
+		registryOptions = append(registryOptions,
+			registryserver.WithNSFrontServers(metrics.NewNetworkServiceRegistryServer(registryMetrics)),
+			registryserver.WithNSEFrontServers(metrics.NewNetworkServiceEndpointRegistryServer(registryMetrics)),
+			registryserver.WithNSERegistryServers(metrics.NewExpirationNetworkServiceEndpointRegistryServer(registryMetrics)),
+		)
+	}
+
+	// Configure rate limits, the throttled requests are counted by the metrics but not audited
+	if config.RegisterRateLimit > 0 || config.FindRateLimit > 0 {
+		limiter := newRateLimiter(config, registryMetrics)
+		registryOptions = append(registryOptions,
+			registryserver.WithNSFrontServers(ratelimit.NewNetworkServiceRegistryServer(limiter)),
+			registryserver.WithNSEFrontServers(ratelimit.NewNetworkServiceEndpointRegistryServer(limiter)),
+		)
+	}
+
+	// Configure audit log
+	var auditor *audit.Auditor
+	if config.AuditLogPath != "" {
+		var closeAuditLog func()
+		auditor, closeAuditLog = newAuditor(ctx, config)
+		defer closeAuditLog()
+		registryOptions = append(registryOptions,
+			registryserver.WithNSFrontServers(audit.NewNetworkServiceRegistryServer(auditor)),
+			registryserver.WithNSEFrontServers(audit.NewNetworkServiceEndpointRegistryServer(auditor)),
+			registryserver.WithNSERegistryServers(audit.NewExpirationNetworkServiceEndpointRegistryServer(auditor)),
+		)
+	}
+
+	// Configure quotas before the WAL, so the rejected registrations are not persisted
+	if config.MaxNSEs > 0 || config.MaxNSEsPerID > 0 || config.MaxNSEsPerService > 0 || len(config.TrustDomainMaxNSEs) > 0 {
+		quotas := newQuotas(config, registryMetrics)
+		registryOptions = append(registryOptions,
+			registryserver.WithNSERegistryServers(quota.NewNetworkServiceEndpointRegistryServer(quotas)))
+	}
+
+	var walLog *wal.Log
+	if config.WALPath != "" {
+		walLog = wal.New(config.WALPath, config.SnapshotPath, wal.WithCompactionThreshold(config.WALCompactionThreshold))
+		registryOptions = append(registryOptions,
+			registryserver.WithNSRegistryServers(wal.NewNetworkServiceRegistryServer(walLog)),
+			registryserver.WithNSERegistryServers(wal.NewNetworkServiceEndpointRegistryServer(walLog)),
+		)
+	}
+
+	var syncer *antientropy.Syncer
+	if len(config.Peers) > 0 {
+		var peers []*url.URL
+		for i := range config.Peers {
+			peers = append(peers, &config.Peers[i])
+		}
+		syncer = antientropy.New(peers,
+			antientropy.WithInterval(config.PeersSyncInterval),
+			antientropy.WithDialOptions(clientOptions...),
+			antientropy.WithAuthorize(peerauth.AuthorizeID(svid.ID)),
+		)
+		registryOptions = append(registryOptions,
+			registryserver.WithNSRegistryServers(antientropy.NewNetworkServiceRegistryServer(syncer)),
+			registryserver.WithNSERegistryServers(antientropy.NewNetworkServiceEndpointRegistryServer(syncer)),
+		)
+	}
+
+	if config.ElectionLockPath != "" {
+		registryOptions = append(registryOptions, electLeader(ctx, config)...)
+	}
+
+	// The standby rejects the writes of the clients until promoted, the entries are mirrored from the primary
+	var standbyRegistry *standby.Standby
+	if config.StandbyPrimaryURL.String() != "" {
+		standbyRegistry = standby.New(&config.StandbyPrimaryURL,
+			standby.WithDialOptions(clientOptions...),
+			standby.WithAuthorize(peerauth.AuthorizeID(svid.ID)),
+		)
+		registryOptions = append(registryOptions,
+			registryserver.WithNSFrontServers(standby.NewNetworkServiceRegistryServer(standbyRegistry)),
+			registryserver.WithNSEFrontServers(standby.NewNetworkServiceEndpointRegistryServer(standbyRegistry)),
+		)
+	}
+
+	var staticCatalog *catalog.Catalog
+	if config.CatalogPath != "" {
+		staticCatalog = catalog.New(config.CatalogPath)
+		registryOptions = append(registryOptions,
+			registryserver.WithNSFrontServers(catalog.NewNetworkServiceRegistryServer(staticCatalog)))
+	}
+
+	registryServer := registryserver.NewServer(
+		ctx,
+		spiffejwt.TokenGeneratorFunc(source, config.MaxTokenLifetime),
+		registryOptions...)
+	registryServer.Register(server)
+
+	// Apply the changed config file and reload the policies on SIGHUP
+	reloader := newConfigReloader(ctx, *configPath, config, registryServer, profiler)
+	if *configPath != "" {
+		go reloader.Run(ctx)
+	}
+	go reloadOnSignal(ctx, policies, reloader)
+
+	// Restore registry entries persisted before the restart
+	restoreRegistry(ctx, config, registryStorage, walLog, registryServer)
+
+	// Apply the static catalog over the restored entries
+	if staticCatalog != nil {
+		if err = staticCatalog.Load(ctx, registryServer); err != nil {
+			logrus.Fatalf("error loading catalog: %+v", err)
+		}
+		go staticCatalog.Run(ctx, registryServer)
+	}
+
+	if syncer != nil {
+		syncer.Register(server, registryServer)
+		go syncer.Run(ctx, registryServer)
+	}
+
+	if standbyRegistry != nil {
+		standbyRegistry.Register(server)
+		standbyRegistry.Start(ctx, registryServer)
+		go promoteOnSignal(ctx, standbyRegistry)
+	}
+
+	if len(config.AdminSpiffeIDs) > 0 || len(config.AdminPolicies) > 0 {
+		newAdminServer(config, registryServer, registryMetrics, auditor).Register(server)
+	}
+
+	// Configure Prometheus metrics
+	if registryMetrics != nil {
+		registryMetrics.CollectRegistry(registryServer)
+		go metrics.ListenAndServe(ctx, config.PrometheusListenOn, registryMetrics)
+	}
+
+	// Configure debug HTTP endpoint
+	if config.DebugHTTPEnabled {
+		go debughttp.ListenAndServe(ctx, config.DebugHTTPListenOn, registryServer)
+	}
+
+	// Check the registry components before serving, so the health service reports the actual readiness
+	setupHealth(ctx, config, registryServer, source, registryStorage, policies, proxyClientOptions)
+
+	for i := 0; i < len(config.ListenOn); i++ {
+		srvErrCh := grpcutils.ListenAndServe(ctx, &config.ListenOn[i], server)
+		exitOnErr(ctx, shutdown, srvErrCh)
+	}
+
+	log.FromContext(ctx).Infof("Startup completed in %v", time.Since(startTime))
+	<-shutdownCtx.Done()
+	shutdownRegistry(ctx, config, server, registryServer, drainer, walLog)
+}
+
+func newRateLimiter(config *Config, registryMetrics *metrics.Metrics) *ratelimit.Limiter {
+	options := []ratelimit.Option{
+		ratelimit.WithRegisterLimit(ratelimit.Limit{Rate: config.RegisterRateLimit, Burst: config.RegisterBurst}),
+		ratelimit.WithFindLimit(ratelimit.Limit{Rate: config.FindRateLimit, Burst: config.FindBurst}),
+		ratelimit.WithByService(config.RateLimitByService),
+	}
+	if registryMetrics != nil {
+		options = append(options, ratelimit.WithOnThrottled(registryMetrics.Throttled))
+	}
+	return ratelimit.New(options...)
+}
+
+func newQuotas(config *Config, registryMetrics *metrics.Metrics) *quota.Quotas {
+	options := []quota.Option{
+		quota.WithLimits(quota.Limits{
+			PerID:      config.MaxNSEsPerID,
+			PerService: config.MaxNSEsPerService,
+			Total:      config.MaxNSEs,
+		}),
+	}
+	trustDomainLimits, err := quota.ParseTrustDomainLimits(config.TrustDomainMaxNSEs)
+	if err != nil {
+		logrus.Fatalf("error parsing trust domain quotas: %+v", err)
+	}
+	for td, limit := range trustDomainLimits {
+		options = append(options, quota.WithTrustDomainLimit(td, limit))
+	}
+	if registryMetrics != nil {
+		options = append(options, quota.WithOnExceeded(registryMetrics.QuotaExceeded))
+	}
+	quotas := quota.New(options...)
+	if registryMetrics != nil {
+		registryMetrics.CollectQuotas(quotas)
+	}
+	return quotas
+}
+
+// setupHealth adds the checks of the registry components, checks them and keeps checking them with the interval
+func setupHealth(ctx context.Context, config *Config, registryServer *registryserver.Server, source x509svid.Source,
+	registryStorage storage.Storage, policies *policyreload.Policies, proxyClientOptions []grpc.DialOption) {
+	registryHealth := registryServer.Health()
+	registryHealth.AddCheck("x509-svid", healthcheck.X509SVIDCheck(source))
+	if files, ok := source.(*svidfile.Source); ok {
+		registryHealth.AddCheck("svid-files", func(context.Context) error {
+			return files.Err()
+		})
+	}
+	registryHealth.AddCheck("storage", func(context.Context) error {
+		_, err := registryStorage.NetworkServices()
+		return err
+	})
+	registryHealth.AddCheck("policies", func(context.Context) error {
+		return policies.Err()
+	})
+	if config.ProxyRegistryURL.String() != "" {
+		cc, err := grpc.NewClient(grpcutils.URLToTarget(&config.ProxyRegistryURL), proxyClientOptions...)
+		if err != nil {
+			logrus.Fatalf("error creating proxy registry client: %+v", err)
+		}
+		go func() {
+			<-ctx.Done()
+			_ = cc.Close()
+		}()
+		registryHealth.AddCheck("proxy-registry", healthcheck.ConnCheck(cc))
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, config.HealthCheckInterval)
+	registryHealth.Check(checkCtx)
+	cancel()
+	go registryHealth.Run(ctx, config.HealthCheckInterval)
+
+	if config.HealthHTTPEnabled {
+		go healthcheck.ListenAndServe(ctx, config.HealthHTTPListenOn, registryHealth)
+	}
+}
+
+// shutdownRegistry reports the registry is not serving, rejects new registrations and watches, waits for in-flight
+// requests until the drain timeout, stops the server and saves the final snapshot
+func shutdownRegistry(ctx context.Context, config *Config, server *grpc.Server, registryServer *registryserver.Server,
+	drainer *drain.Drainer, walLog *wal.Log) {
+	log.FromContext(ctx).Info("Shutting down")
+	registryServer.SetServing(false)
+	drainer.Drain()
+
+	drainCtx, cancel := context.WithTimeout(ctx, config.DrainTimeout)
+	defer cancel()
+	if err := drainer.Wait(drainCtx); err != nil {
+		log.FromContext(ctx).Warnf("drain timeout exceeded: %s", err.Error())
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-drainCtx.Done():
+		log.FromContext(ctx).Warn("drain timeout exceeded, closing the remaining connections")
+		server.Stop()
+		<-stopped
+	}
+
+	// The final snapshot is saved after the server has stopped, so no registration handled by it is lost
+	if config.SnapshotOnShutdown {
+		var err error
+		switch {
+		case walLog != nil:
+			err = walLog.Compact(ctx, registryServer)
+		case config.SnapshotPath != "":
+			err = snapshot.Flush(ctx, config.SnapshotPath, registryServer)
+		}
+		if err != nil {
+			log.FromContext(ctx).Errorf("failed to save final snapshot: %s", err.Error())
+		}
+	}
+	log.FromContext(ctx).Info("Shutdown completed")
+}
+
+// x509Source is the source of the SVID of the registry and the trust bundles
+type x509Source interface {
+	x509svid.Source
+	x509bundle.Source
+}
+
+// newX509Source creates the X.509 source selected by the config. The files source is reloaded on changes until the
+// source is closed.
+func newX509Source(ctx context.Context, config *Config) (x509Source, func(), error) {
+	if config.SVIDSource == svidSourceFiles {
+		source, err := svidfile.New(config.SVIDCertPath, config.SVIDKeyPath, config.TrustBundlePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		go source.Run(ctx)
+		return source, cancel, nil
+	}
+	source, err := workloadapi.NewX509Source(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get x509 source from SPIRE agent")
+	}
+	return source, func() { _ = source.Close() }, nil
+}
+
+func newClientOptions(source x509Source, config *Config, authorizer tlsconfig.Authorizer) []grpc.DialOption {
+	tlsClientConfig := tlsconfig.MTLSClientConfig(source, source, authorizer)
+	tlsClientConfig.MinVersion = tls.VersionTLS12
+
+	return append(
 		tracing.WithTracingDial(),
 		grpc.WithBlock(),
 		grpc.WithDefaultCallOptions(
@@ -167,29 +601,161 @@ func main() {
 		grpcfd.WithChainStreamInterceptor(),
 		grpcfd.WithChainUnaryInterceptor(),
 	)
+}
 
-	memory.NewServer(
-		ctx,
-		spiffejwt.TokenGeneratorFunc(source, config.MaxTokenLifetime),
-		memory.WithAuthorizeNSERegistryServer(authorize.NewNetworkServiceEndpointRegistryServer(
-			authorize.WithPolicies(config.RegistryServerPolicies...))),
-		memory.WithAuthorizeNSERegistryClient(authorize.NewNetworkServiceEndpointRegistryClient(
-			authorize.WithPolicies(config.RegistryClientPolicies...))),
-		memory.WithAuthorizeNSRegistryServer(authorize.NewNetworkServiceRegistryServer(
-			authorize.WithPolicies(config.RegistryServerPolicies...))),
-		memory.WithAuthorizeNSRegistryClient(authorize.NewNetworkServiceRegistryClient(
-			authorize.WithPolicies(config.RegistryClientPolicies...))),
-		memory.WithDefaultExpiration(time.Minute),
-		memory.WithProxyRegistryURL(&config.ProxyRegistryURL),
-		memory.WithDialOptions(clientOptions...)).Register(server)
+func joinCluster(ctx context.Context, config *Config, source x509Source, id spiffeid.ID,
+	registryStorage storage.Storage, clientOptions []grpc.DialOption) *cluster.Node {
+	members, err := cluster.ParseMembers(config.ClusterMembers)
+	if err != nil {
+		logrus.Fatalf("error parsing cluster members: %+v", err)
+	}
 
-	for i := 0; i < len(config.ListenOn); i++ {
-		srvErrCh := grpcutils.ListenAndServe(ctx, &config.ListenOn[i], server)
-		exitOnErr(ctx, cancel, srvErrCh)
+	// Only the registries sharing the SPIFFE ID of this registry are allowed to join the cluster
+	raftServerConfig := tlsconfig.MTLSServerConfig(source, source, tlsconfig.AuthorizeID(id))
+	raftServerConfig.MinVersion = tls.VersionTLS12
+	raftClientConfig := tlsconfig.MTLSClientConfig(source, source, tlsconfig.AuthorizeID(id))
+	raftClientConfig.MinVersion = tls.VersionTLS12
+
+	node, err := cluster.New(ctx, config.ClusterNodeID, members, registryStorage,
+		cluster.WithTLSConfig(raftServerConfig, raftClientConfig),
+		cluster.WithDialOptions(clientOptions...),
+		cluster.WithAuthorize(peerauth.AuthorizeID(id)),
+		cluster.WithDataDir(config.ClusterDataDir),
+		// The registry server sweeps the expired NSEs on the leader through the chain, see registryserver.WithLeader
+		cluster.WithExpirePeriod(0),
+	)
+	if err != nil {
+		logrus.Fatalf("error joining cluster: %+v", err)
 	}
+	return node
+}
 
-	log.FromContext(ctx).Infof("Startup completed in %v", time.Since(startTime))
-	<-ctx.Done()
+func electLeader(ctx context.Context, config *Config) []registryserver.Option {
+	advertiseURL := &config.ElectionAdvertiseURL
+	if advertiseURL.String() == "" {
+		advertiseURL = &config.ListenOn[0]
+	}
+	elector := election.New(election.NewFileLease(config.ElectionLockPath), advertiseURL.String())
+	go elector.Run(ctx)
+
+	return []registryserver.Option{
+		registryserver.WithNSFrontServers(election.NewNetworkServiceRegistryServer(elector)),
+		registryserver.WithNSEFrontServers(election.NewNetworkServiceEndpointRegistryServer(elector)),
+	}
+}
+
+func restoreRegistry(ctx context.Context, config *Config, registryStorage storage.Storage, walLog *wal.Log,
+	registryServer *registryserver.Server) {
+	// The cluster node restores its storage from the raft state and the leader expires the endpoints, so the entries
+	// are not proposed to the cluster again
+	if config.ClusterNodeID == "" {
+		if err := storage.Restore(ctx, registryStorage, registryServer); err != nil {
+			logrus.Fatalf("error restoring storage: %+v", err)
+		}
+	}
+	switch {
+	case walLog != nil:
+		if err := walLog.Restore(ctx, registryServer); err != nil {
+			logrus.Fatalf("error restoring write-ahead log: %+v", err)
+		}
+		go walLog.Run(ctx, registryServer)
+	case config.SnapshotPath != "":
+		if err := snapshot.Restore(ctx, config.SnapshotPath, registryServer); err != nil {
+			log.FromContext(ctx).Errorf("failed to restore snapshot: %s", err.Error())
+		}
+		go snapshot.Run(ctx, config.SnapshotPath, config.SnapshotInterval, registryServer)
+	}
+}
+
+func newAdminServer(config *Config, registryServer *registryserver.Server, registryMetrics *metrics.Metrics,
+	auditor *audit.Auditor) *admin.Server {
+	var adminIDs []spiffeid.ID
+	for _, s := range config.AdminSpiffeIDs {
+		id, err := spiffeid.FromString(s)
+		if err != nil {
+			logrus.Fatalf("error parsing admin SPIFFE ID: %+v", err)
+		}
+		adminIDs = append(adminIDs, id)
+	}
+	adminOptions := []admin.Option{admin.WithAdminIDs(adminIDs...)}
+	if len(config.AdminPolicies) > 0 {
+		policies, err := admin.PoliciesFromPaths(config.AdminPolicies...)
+		if err != nil {
+			logrus.Fatalf("error loading admin policies: %+v", err)
+		}
+		adminOptions = append(adminOptions, admin.WithPolicies(policies...))
+	}
+	if registryMetrics != nil {
+		adminOptions = append(adminOptions, admin.WithOnDenied(registryMetrics.Denied))
+	}
+	if auditor != nil {
+		adminOptions = append(adminOptions, admin.WithOnForceUnregister(func(ctx context.Context, kind admin.Kind, name string) {
+			auditor.ForceUnregister(ctx, string(kind), name)
+		}))
+	}
+	return admin.New(registryServer, adminOptions...)
+}
+
+func loadTTLPolicies(config *Config) []*ttl.Policy {
+	if config.TTLPoliciesPath == "" {
+		return nil
+	}
+	policies, err := ttl.Load(config.TTLPoliciesPath)
+	if err != nil {
+		logrus.Fatalf("error loading TTL policies: %+v", err)
+	}
+	return policies
+}
+
+func newAuditor(ctx context.Context, config *Config) (auditor *audit.Auditor, closeFunc func()) {
+	if config.AuditLogPath == "-" {
+		return audit.New(os.Stdout), func() {}
+	}
+	auditLog, err := audit.OpenRotatingFile(config.AuditLogPath, config.AuditLogMaxSize, config.AuditLogMaxBackups)
+	if err != nil {
+		logrus.Fatalf("error opening audit log: %+v", err)
+	}
+	return audit.New(auditLog), func() {
+		if err := auditLog.Close(); err != nil {
+			log.FromContext(ctx).Error(err.Error())
+		}
+	}
+}
+
+func reloadOnSignal(ctx context.Context, policies *policyreload.Policies, reloader *configReloader) {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGHUP)
+	defer signal.Stop(signalCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signalCh:
+			if err := policies.Reload(); err != nil {
+				log.FromContext(ctx).Errorf("failed to reload policies, keeping the current ones: %s", err.Error())
+			} else {
+				log.FromContext(ctx).Info("policies are reloaded")
+			}
+			if err := reloader.Reload(ctx); err != nil {
+				log.FromContext(ctx).Errorf("failed to reload config, keeping the current one: %s", err.Error())
+			}
+		}
+	}
+}
+
+func promoteOnSignal(ctx context.Context, standbyRegistry *standby.Standby) {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGWINCH)
+	defer signal.Stop(signalCh)
+
+	select {
+	case <-ctx.Done():
+	case <-standbyRegistry.Promoted():
+	case <-signalCh:
+		log.FromContext(ctx).Info("promoting standby registry")
+		standbyRegistry.Promote()
+	}
 }
 
 func exitOnErr(ctx context.Context, cancel context.CancelFunc, errCh <-chan error) {
@@ -201,8 +767,10 @@ func exitOnErr(ctx context.Context, cancel context.CancelFunc, errCh <-chan erro
 	}
 	// Otherwise wait for an error in the background to log and cancel
 	go func(ctx context.Context, errCh <-chan error) {
-		err := <-errCh
-		log.FromContext(ctx).Error(err)
-		cancel()
+		// The channel is closed without an error when the server is stopped
+		if err := <-errCh; err != nil {
+			log.FromContext(ctx).Error(err)
+			cancel()
+		}
 	}(ctx, errCh)
 }
@@ -2,6 +2,8 @@
 //
 // Copyright (c) 2022-2023 Cisco Systems, Inc.
 //
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
 // SPDX-License-Identifier: Apache-2.0
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -23,8 +25,11 @@ package main_test
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,6 +48,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/networkservicemesh/api/pkg/api/registry"
 	registryclient "github.com/networkservicemesh/sdk/pkg/registry/chains/client"
@@ -51,11 +57,14 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/registry/common/refresh"
 	"github.com/networkservicemesh/sdk/pkg/registry/core/next"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/sandbox"
 	"github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 	"github.com/networkservicemesh/sdk/pkg/tools/spire"
 	"github.com/networkservicemesh/sdk/pkg/tools/token"
 
 	main "github.com/networkservicemesh/cmd-registry-memory"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+	"github.com/networkservicemesh/cmd-registry-memory/internal/storage"
 )
 
 type RegistryTestSuite struct {
@@ -66,10 +75,15 @@ type RegistryTestSuite struct {
 	x509bundle x509bundle.Source
 	config     main.Config
 	spireErrCh <-chan error
-	sutErrCh   <-chan error
+	sutErrChs  []<-chan error
 }
 
 func (t *RegistryTestSuite) SetupSuite() {
+	t.setupSuite(os.Environ())
+}
+
+// setupSuite runs spire and a registry for each of the environs
+func (t *RegistryTestSuite) setupSuite(environs ...[]string) {
 	logrus.SetFormatter(&nested.Formatter{})
 	log.EnableTracing(true)
 	t.ctx, t.cancel = context.WithCancel(context.Background())
@@ -99,13 +113,16 @@ func (t *RegistryTestSuite) SetupSuite() {
 
 	// Run system under test (sut)
 	cmdStr := "registry-memory"
-	t.sutErrCh = exechelper.Start(cmdStr,
-		exechelper.WithContext(t.ctx),
-		exechelper.WithEnvirons(os.Environ()...),
-		exechelper.WithStdout(os.Stdout),
-		exechelper.WithStderr(os.Stderr),
-	)
-	require.Len(t.T(), t.sutErrCh, 0)
+	for _, environ := range environs {
+		sutErrCh := exechelper.Start(cmdStr,
+			exechelper.WithContext(t.ctx),
+			exechelper.WithEnvirons(environ...),
+			exechelper.WithStdout(os.Stdout),
+			exechelper.WithStderr(os.Stderr),
+		)
+		require.Len(t.T(), sutErrCh, 0)
+		t.sutErrChs = append(t.sutErrChs, sutErrCh)
+	}
 
 	// Get config from env
 	require.NoError(t.T(), envconfig.Process("registry-memory", &t.config))
@@ -113,10 +130,12 @@ func (t *RegistryTestSuite) SetupSuite() {
 
 func (t *RegistryTestSuite) TearDownSuite() {
 	t.cancel()
-	for {
-		_, ok := <-t.sutErrCh
-		if !ok {
-			break
+	for _, sutErrCh := range t.sutErrChs {
+		for {
+			_, ok := <-sutErrCh
+			if !ok {
+				break
+			}
 		}
 	}
 	for {
@@ -339,3 +358,234 @@ func (t *RegistryTestSuite) TestNetworkServiceEndpointClientRefreshingTime() {
 func TestRegistryTestSuite(t *testing.T) {
 	suite.Run(t, new(RegistryTestSuite))
 }
+
+// ClusterTestSuite runs the registry flows against a cluster of three registries
+type ClusterTestSuite struct {
+	RegistryTestSuite
+	listenOn []url.URL
+}
+
+func (t *ClusterTestSuite) SetupSuite() {
+	const clusterSize = 3
+
+	dir := t.T().TempDir()
+	var members []string
+	for i := 0; i < clusterSize; i++ {
+		// The free port is released right before the registry listens on it
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t.T(), err)
+		members = append(members, fmt.Sprintf("unix://%s/registry-%d.sock=%s", dir, i, ln.Addr().String()))
+		require.NoError(t.T(), ln.Close())
+	}
+
+	var environs [][]string
+	for i := 0; i < clusterSize; i++ {
+		listenOn, err := url.Parse(fmt.Sprintf("unix://%s/registry-%d.sock", dir, i))
+		require.NoError(t.T(), err)
+		t.listenOn = append(t.listenOn, *listenOn)
+		environs = append(environs, append(os.Environ(),
+			"NSM_LISTEN_ON="+listenOn.String(),
+			"NSM_CLUSTER_NODE_ID="+listenOn.String(),
+			"NSM_CLUSTER_MEMBERS="+strings.Join(members, ","),
+			"NSM_CLUSTER_DATA_DIR="+filepath.Join(dir, fmt.Sprintf("raft-%d", i)),
+		))
+	}
+	t.setupSuite(environs...)
+
+	t.config.ListenOn = t.listenOn[:1]
+}
+
+func (t *ClusterTestSuite) nseClient(ctx context.Context, listenOn *url.URL) registry.NetworkServiceEndpointRegistryClient {
+	cc, err := grpc.DialContext(ctx,
+		listenOn.String(),
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsconfig.MTLSClientConfig(t.x509source, t.x509bundle, tlsconfig.AuthorizeAny()))),
+		grpc.WithDefaultCallOptions(
+			grpc.WaitForReady(true),
+			grpc.PerRPCCredentials(token.NewPerRPCCredentials(spiffejwt.TokenGeneratorFunc(t.x509source, t.config.MaxTokenLifetime))),
+		),
+		grpcfd.WithChainStreamInterceptor(),
+		grpcfd.WithChainUnaryInterceptor(),
+	)
+	t.Require().NoError(err)
+	go func() {
+		<-ctx.Done()
+		_ = cc.Close()
+	}()
+	return next.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(cc),
+	)
+}
+
+func (t *ClusterTestSuite) TestNetworkServiceEndpointRefreshOnAnotherRegistry() {
+	ctx, cancel := context.WithTimeout(t.ctx, 100*time.Second)
+	defer cancel()
+
+	var clients []registry.NetworkServiceEndpointRegistryClient
+	for i := range t.listenOn {
+		clients = append(clients, t.nseClient(ctx, &t.listenOn[i]))
+	}
+
+	// The endpoint registered through one registry is refreshed through the next one before it expires on the first
+	// one, the registrations and refreshes reach the leader from the followers
+	for i := range clients {
+		name := fmt.Sprintf("nse-refresh-%d", i)
+		requestCtx, requestCancel := context.WithTimeout(ctx, time.Second)
+		_, err := clients[i].Register(requestCtx, &registry.NetworkServiceEndpoint{
+			Name:                name,
+			Url:                 "tcp://127.0.0.1",
+			NetworkServiceNames: []string{"ns-1"},
+			ExpirationTime:      timestamppb.New(time.Now().Add(2 * time.Second)),
+		})
+		requestCancel()
+		t.Require().NoError(err)
+		_, err = clients[(i+1)%len(clients)].Register(ctx, &registry.NetworkServiceEndpoint{
+			Name:                name,
+			Url:                 "tcp://127.0.0.1",
+			NetworkServiceNames: []string{"ns-1"},
+			ExpirationTime:      timestamppb.New(time.Now().Add(time.Hour)),
+		})
+		t.Require().NoError(err)
+	}
+
+	t.Never(func() bool {
+		for _, client := range clients {
+			for i := range clients {
+				stream, err := client.Find(ctx, &registry.NetworkServiceEndpointQuery{
+					NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: fmt.Sprintf("nse-refresh-%d", i)},
+				})
+				t.Require().NoError(err)
+				if len(registry.ReadNetworkServiceEndpointList(stream)) == 0 {
+					return true
+				}
+			}
+		}
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+
+	for i := range clients {
+		_, err := clients[i].Unregister(ctx, &registry.NetworkServiceEndpoint{Name: fmt.Sprintf("nse-refresh-%d", i)})
+		t.Require().NoError(err)
+	}
+}
+
+func TestClusterTestSuite(t *testing.T) {
+	suite.Run(t, new(ClusterTestSuite))
+}
+
+// ExpirationTestSuite runs the registry flows against a registry with short expirations
+type ExpirationTestSuite struct {
+	RegistryTestSuite
+}
+
+const (
+	testDefaultExpiration = 2 * time.Second
+	testMaxExpiration     = 3 * time.Second
+)
+
+func (t *ExpirationTestSuite) SetupSuite() {
+	t.setupSuite(append(os.Environ(),
+		"NSM_DEFAULT_EXPIRATION="+testDefaultExpiration.String(),
+		"NSM_MAX_EXPIRATION="+testMaxExpiration.String(),
+	))
+	t.config.DefaultExpiration = testDefaultExpiration
+	t.config.MaxExpiration = testMaxExpiration
+}
+
+func (t *ExpirationTestSuite) nseClient(ctx context.Context) registry.NetworkServiceEndpointRegistryClient {
+	cc, err := grpc.DialContext(ctx,
+		t.config.ListenOn[0].String(),
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsconfig.MTLSClientConfig(t.x509source, t.x509bundle, tlsconfig.AuthorizeAny()))),
+		grpc.WithDefaultCallOptions(
+			grpc.WaitForReady(true),
+			grpc.PerRPCCredentials(token.NewPerRPCCredentials(spiffejwt.TokenGeneratorFunc(t.x509source, t.config.MaxTokenLifetime))),
+		),
+		grpcfd.WithChainStreamInterceptor(),
+		grpcfd.WithChainUnaryInterceptor(),
+	)
+	t.NoError(err)
+	return next.NewNetworkServiceEndpointRegistryClient(
+		grpcmetadata.NewNetworkServiceEndpointRegistryClient(),
+		registry.NewNetworkServiceEndpointRegistryClient(cc),
+	)
+}
+
+func (t *ExpirationTestSuite) TestDefaultExpiration() {
+	ctx, cancel := context.WithTimeout(t.ctx, 100*time.Second)
+	defer cancel()
+	client := t.nseClient(ctx)
+
+	registrationTime := time.Now()
+	result, err := client.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-default-expiration",
+		Url:                 "tcp://127.0.0.1",
+		NetworkServiceNames: []string{"ns-1"},
+	})
+	t.NoError(err)
+	t.WithinDuration(registrationTime.Add(testDefaultExpiration), result.GetExpirationTime().AsTime(), time.Second)
+
+	t.Eventually(func() bool {
+		stream, findErr := client.Find(context.Background(), &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: result.Name}})
+		t.NoError(findErr)
+		return len(registry.ReadNetworkServiceEndpointList(stream)) == 0
+	}, testDefaultExpiration+5*time.Second, 100*time.Millisecond)
+}
+
+func (t *ExpirationTestSuite) TestMaxExpiration() {
+	ctx, cancel := context.WithTimeout(t.ctx, 100*time.Second)
+	defer cancel()
+	client := t.nseClient(ctx)
+
+	registrationTime := time.Now()
+	result, err := client.Register(context.Background(), &registry.NetworkServiceEndpoint{
+		Name:                "nse-max-expiration",
+		Url:                 "tcp://127.0.0.1",
+		NetworkServiceNames: []string{"ns-1"},
+		ExpirationTime:      timestamppb.New(registrationTime.Add(72 * time.Hour)),
+	})
+	t.NoError(err)
+	t.WithinDuration(registrationTime.Add(testMaxExpiration), result.GetExpirationTime().AsTime(), time.Second)
+
+	t.Eventually(func() bool {
+		stream, findErr := client.Find(context.Background(), &registry.NetworkServiceEndpointQuery{NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: result.Name}})
+		t.NoError(findErr)
+		return len(registry.ReadNetworkServiceEndpointList(stream)) == 0
+	}, testMaxExpiration+5*time.Second, 100*time.Millisecond)
+}
+
+func TestExpirationTestSuite(t *testing.T) {
+	suite.Run(t, new(ExpirationTestSuite))
+}
+
+// TestExpirePeriod checks that the endpoints stored bypassing the registry chain, e.g. replicated by the cluster, are
+// expired only by the periodic check
+func TestExpirePeriod(t *testing.T) {
+	const expirePeriod = 100 * time.Millisecond
+
+	for _, period := range []time.Duration{0, expirePeriod} {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		registryStorage := storage.NewMemory()
+		registryserver.NewServer(ctx, sandbox.GenerateTestToken,
+			registryserver.WithStorage(registryStorage),
+			registryserver.WithExpirePeriod(period),
+		)
+		require.NoError(t, registryStorage.StoreNetworkServiceEndpoint(&registry.NetworkServiceEndpoint{
+			Name:                "nse-1",
+			NetworkServiceNames: []string{"ns-1"},
+			ExpirationTime:      timestamppb.New(time.Now().Add(-time.Second)),
+		}))
+
+		stored := func() bool {
+			nses, err := registryStorage.NetworkServiceEndpoints()
+			require.NoError(t, err)
+			return len(nses) == 1
+		}
+		if period == 0 {
+			require.Never(t, func() bool { return !stored() }, 10*expirePeriod, expirePeriod/10)
+		} else {
+			require.Eventually(t, func() bool { return !stored() }, 10*expirePeriod, expirePeriod/10)
+		}
+		cancel()
+	}
+}
@@ -2,47 +2,121 @@
 package imports
 
 import (
+	_ "bufio"
+	_ "bytes"
 	_ "context"
+	_ "crypto/ecdsa"
+	_ "crypto/elliptic"
+	_ "crypto/rand"
 	_ "crypto/tls"
+	_ "crypto/x509"
+	_ "embed"
+	_ "encoding/binary"
+	_ "encoding/json"
+	_ "encoding/pem"
+	_ "flag"
 	_ "fmt"
 	_ "github.com/antonfisher/nested-logrus-formatter"
 	_ "github.com/edwarnicke/exechelper"
+	_ "github.com/edwarnicke/genericsync"
 	_ "github.com/edwarnicke/grpcfd"
+	_ "github.com/edwarnicke/serialize"
+	_ "github.com/fsnotify/fsnotify"
+	_ "github.com/golang-jwt/jwt/v4"
+	_ "github.com/golang/protobuf/ptypes/empty"
 	_ "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/google/uuid"
+	_ "github.com/hashicorp/go-hclog"
+	_ "github.com/hashicorp/raft"
 	_ "github.com/kelseyhightower/envconfig"
+	_ "github.com/networkservicemesh/api/pkg/api"
 	_ "github.com/networkservicemesh/api/pkg/api/registry"
+	_ "github.com/networkservicemesh/sdk/pkg/registry"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/chains/client"
-	_ "github.com/networkservicemesh/sdk/pkg/registry/chains/memory"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/authorize"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/begin"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/clientconn"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/clienturl"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/connect"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/dial"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/expire"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/grpcmetadata"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/memory"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/common/refresh"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/setpayload"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/setregistrationtime"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/common/updatepath"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/core/adapters"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/core/chain"
 	_ "github.com/networkservicemesh/sdk/pkg/registry/core/next"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/core/streamchannel"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/switchcase"
+	_ "github.com/networkservicemesh/sdk/pkg/registry/utils/metadata"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/clock"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/debug"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/grpcutils"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/interdomain"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/log"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/matchutils"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/opa"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/opentelemetry"
-	_ "github.com/networkservicemesh/sdk/pkg/tools/pprofutils"
+	_ "github.com/networkservicemesh/sdk/pkg/tools/sandbox"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/spiffejwt"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/spire"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/token"
 	_ "github.com/networkservicemesh/sdk/pkg/tools/tracing"
+	_ "github.com/pkg/errors"
+	_ "github.com/prometheus/client_golang/prometheus"
+	_ "github.com/prometheus/client_golang/prometheus/promhttp"
 	_ "github.com/sirupsen/logrus"
 	_ "github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
+	_ "github.com/spiffe/go-spiffe/v2/spiffeid"
 	_ "github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 	_ "github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	_ "github.com/spiffe/go-spiffe/v2/workloadapi"
 	_ "github.com/stretchr/testify/require"
 	_ "github.com/stretchr/testify/suite"
+	_ "go.etcd.io/bbolt"
+	_ "golang.org/x/time/rate"
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
 	_ "google.golang.org/grpc"
+	_ "google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/connectivity"
 	_ "google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health"
 	_ "google.golang.org/grpc/health/grpc_health_v1"
+	_ "google.golang.org/grpc/metadata"
+	_ "google.golang.org/grpc/peer"
+	_ "google.golang.org/grpc/status"
+	_ "google.golang.org/protobuf/encoding/protojson"
+	_ "google.golang.org/protobuf/proto"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+	_ "io"
+	_ "math/big"
+	_ "net"
+	_ "net/http"
+	_ "net/http/httptest"
+	_ "net/http/pprof"
 	_ "net/url"
 	_ "os"
 	_ "os/signal"
+	_ "path"
 	_ "path/filepath"
+	_ "reflect"
+	_ "regexp"
+	_ "sigs.k8s.io/yaml"
+	_ "slices"
+	_ "sort"
+	_ "strconv"
+	_ "strings"
+	_ "sync"
+	_ "sync/atomic"
 	_ "syscall"
 	_ "testing"
 	_ "time"
+	_ "unicode"
 )
@@ -0,0 +1,235 @@
+// Copyright (c) 2026 OpenInfra Foundation Europe. All rights reserved.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/log/logruslogger"
+
+	"github.com/networkservicemesh/cmd-registry-memory/internal/registryserver"
+)
+
+// reloadableFields are the config fields applied at runtime, the other changed fields require a restart
+var reloadableFields = map[string]struct{}{
+	"LogLevel":          {},
+	"ExpirePeriod":      {},
+	"DefaultExpiration": {},
+	"PprofEnabled":      {},
+	"PprofListenOn":     {},
+}
+
+// configReloader reloads the config file and applies the changed reloadable settings
+type configReloader struct {
+	configPath     string
+	registryServer *registryserver.Server
+	pprof          *pprofServer
+
+	mu          sync.Mutex
+	current     *Config
+	levelCancel context.CancelFunc
+}
+
+func newConfigReloader(ctx context.Context, configPath string, config *Config, registryServer *registryserver.Server, pprof *pprofServer) *configReloader {
+	r := &configReloader{
+		configPath:     configPath,
+		registryServer: registryServer,
+		pprof:          pprof,
+		current:        config,
+	}
+	r.setupLevelChangeOnSignal(ctx, config.LogLevel)
+	return r
+}
+
+// setupLevelChangeOnSignal toggles the trace level on SIGUSR1 and restores the configured level on SIGUSR2
+func (r *configReloader) setupLevelChangeOnSignal(ctx context.Context, logLevel string) {
+	if r.levelCancel != nil {
+		r.levelCancel()
+	}
+	level, _ := logrus.ParseLevel(logLevel)
+	ctx, r.levelCancel = context.WithCancel(ctx)
+	logruslogger.SetupLevelChangeOnSignal(ctx, map[os.Signal]logrus.Level{
+		syscall.SIGUSR1: logrus.TraceLevel,
+		syscall.SIGUSR2: level,
+	})
+}
+
+// Reload loads the config and applies the changed reloadable settings. The changed settings requiring a restart are
+// reported and kept unapplied, so they are reported again on the next reload. The reloads on file changes and on
+// signals are serialized, so the latest loaded config is the applied one.
+func (r *configReloader) Reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	config, err := loadConfig(r.configPath)
+	if err != nil {
+		return err
+	}
+	fields, err := configFields()
+	if err != nil {
+		return err
+	}
+
+	current := reflect.ValueOf(r.current).Elem()
+	changed := reflect.ValueOf(config).Elem()
+	applied := *r.current
+	var reloaded, restartRequired []string
+	for _, f := range fields {
+		if reflect.DeepEqual(current.FieldByName(f.name).Interface(), changed.FieldByName(f.name).Interface()) {
+			continue
+		}
+		if _, ok := reloadableFields[f.name]; !ok {
+			restartRequired = append(restartRequired, f.String())
+			continue
+		}
+		reflect.ValueOf(&applied).Elem().FieldByName(f.name).Set(changed.FieldByName(f.name))
+		reloaded = append(reloaded, f.String())
+	}
+	if len(reloaded) == 0 && len(restartRequired) == 0 {
+		log.FromContext(ctx).Debug("config is not changed")
+		return nil
+	}
+
+	if err := r.apply(ctx, &applied); err != nil {
+		return err
+	}
+	r.current = &applied
+
+	if len(reloaded) > 0 {
+		log.FromContext(ctx).Infof("config is reloaded, applied changed settings: %s", strings.Join(reloaded, ", "))
+	}
+	if len(restartRequired) > 0 {
+		log.FromContext(ctx).Warnf("changed settings require a restart to be applied: %s", strings.Join(restartRequired, ", "))
+	}
+	return nil
+}
+
+// apply applies the reloadable settings of the config which differ from the current ones
+func (r *configReloader) apply(ctx context.Context, config *Config) error {
+	if config.DefaultExpiration != r.current.DefaultExpiration {
+		if err := r.registryServer.SetDefaultExpiration(config.DefaultExpiration); err != nil {
+			return errors.Wrap(err, "failed to apply default expiration")
+		}
+	}
+	if config.ExpirePeriod != r.current.ExpirePeriod {
+		r.registryServer.SetExpirePeriod(config.ExpirePeriod)
+	}
+	if config.LogLevel != r.current.LogLevel {
+		level, _ := logrus.ParseLevel(config.LogLevel)
+		logrus.SetLevel(level)
+		r.setupLevelChangeOnSignal(ctx, config.LogLevel)
+	}
+	if config.PprofEnabled != r.current.PprofEnabled || config.PprofListenOn != r.current.PprofListenOn {
+		r.pprof.Set(ctx, config.PprofEnabled, config.PprofListenOn)
+	}
+	return nil
+}
+
+// Run watches the directory of the config file and reloads it on changes until the context is done. The directory is
+// watched as the config maps of Kubernetes replace the file by swapping symlinks.
+func (r *configReloader) Run(ctx context.Context) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.FromContext(ctx).Errorf("failed to watch config file: %s", err.Error())
+		return
+	}
+	defer func() { _ = watcher.Close() }()
+
+	if err := watcher.Add(filepath.Dir(r.configPath)); err != nil {
+		log.FromContext(ctx).Errorf("failed to watch config file %s: %s", r.configPath, err.Error())
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-watcher.Errors:
+			log.FromContext(ctx).Warnf("config file watch error: %s", err.Error())
+		case <-watcher.Events:
+			if err := r.Reload(ctx); err != nil {
+				log.FromContext(ctx).Errorf("failed to reload config, keeping the current one: %s", err.Error())
+			}
+		}
+	}
+}
+
+// pprofServer serves pprof handlers and can be enabled, disabled and moved to another address at runtime
+type pprofServer struct {
+	mu     sync.Mutex
+	server *http.Server
+}
+
+// Set starts or stops serving pprof on the address, the running server is stopped if the address changes
+func (p *pprofServer) Set(ctx context.Context, enabled bool, listenOn string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.server != nil {
+		if enabled && p.server.Addr == listenOn {
+			return
+		}
+		_ = p.server.Close()
+		p.server = nil
+		log.FromContext(ctx).Info("Profiler is disabled")
+	}
+	if !enabled {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	server := &http.Server{
+		Addr:        listenOn,
+		Handler:     mux,
+		ReadTimeout: 10 * time.Second,
+		// Profiles and traces are collected for the requested number of seconds
+		WriteTimeout: time.Minute,
+	}
+	p.server = server
+
+	log.FromContext(ctx).Infof("Profiler is enabled. Listening on %s", listenOn)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.FromContext(ctx).Errorf("Failed to start profiler: %s", err.Error())
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+}